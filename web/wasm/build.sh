@@ -38,7 +38,7 @@ export GOARCH=wasm
 echo "📦 Building main.wasm..."
 
 # Build the WASM binary
-$GO_BIN build -o main.wasm main.go
+$GO_BIN build -o main.wasm .
 
 if [ $? -eq 0 ]; then
     echo "✅ Successfully built main.wasm"
@@ -1,9 +1,19 @@
+//go:build js && wasm
+
 package main
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"html"
 	"log"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,45 +22,114 @@ import (
 )
 
 type AudioPipeApp struct {
-	transcriptionData      *TranscriptionData
-	consolidatedData       []ConsolidatedSegment
-	audioData              *AudioData
-	currentView            string
-	searchQuery            string
-	isDarkTheme            bool
-	statistics             Statistics
-	speakerColors          map[string]string
-	isPlaying              bool
-	currentTime            float64
-	consolidationThreshold float64
-	isConsolidated         bool
+	transcriptionData        *TranscriptionData
+	consolidatedData         []ConsolidatedSegment
+	audioData                *AudioData
+	currentView              string
+	searchQuery              string
+	isDarkTheme              bool
+	statistics               Statistics
+	speakerColors            map[string]string
+	isPlaying                bool
+	currentTime              float64
+	consolidationThreshold   float64
+	consolidationMaxDuration float64
+	consolidationMinWords    int
+	consolidateBySpeaker     bool
+	isConsolidated           bool
+	selectedSegments         map[int]bool
+	maxSegmentLength         int
+	lowConfidenceThreshold   float64
+	lowConfidenceOnly        bool
+	speakerFilter            string
+	clickToPlay              bool
+	scaleToAudio             bool
+	activeMatch              int
+	subtitleMaxChars         int
+	subtitleMaxLines         int
+	undoStack                []UndoSnapshot
+	loopRange                *TimeRange
+	durationPrecision        int
+	showMilliseconds         bool
+	transcriptionFileName    string
+	transcriptions           []LoadedTranscription
+	activeTranscription      int
+	volume                   float64
+	silenceThreshold         float64
+	zoom                     float64
+	virtualized              bool
+	visibleRows              []int
+	virtualStart             int
+	virtualEnd               int
+	timelineRowHeight        float64
+	virtualFramePending      bool
+	preMuteVolume            float64
+	resizeTimer              js.Value
+	searchTimer              js.Value
+	debouncedSearch          js.Func
+	contextMenuTime          float64
+	bookmarks                []Bookmark
+	pendingDropAudio         bool
+	pendingDropTranscripts   int
+	captionKey               string
+	captionWord              int
+	pendingStartTime         float64
+	skipSilence              bool
+	silenceGaps              []Gap
+	silenceSkipExempt        *Gap
+	soloedSpeakers           map[string]bool
+	mutedSpeakers            map[string]bool
+	normalizeImportedText    bool
+	colorByConfidence        bool
+	shortcutOverrides        map[string]string
+}
+
+type TimeRange struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+type UndoSnapshot struct {
+	Segments      []Segment
+	SpeakerColors map[string]string
+}
+
+type Bookmark struct {
+	Time  float64 `json:"time"`
+	Label string  `json:"label,omitempty"`
 }
 
-type TranscriptionData struct {
-	Segments []Segment `json:"segments"`
+type KeyboardShortcut struct {
+	Action      string
+	Keys        []string
+	Label       string
+	Description string
+	Run         func()
 }
 
-type Segment struct {
-	Speaker string  `json:"speaker"`
-	Start   float64 `json:"start"`
-	End     float64 `json:"end"`
-	Text    string  `json:"text"`
+type RecentFile struct {
+	Name     string `json:"name"`
+	LoadedAt string `json:"loadedAt"`
+	Cached   bool   `json:"cached"`
 }
 
-type ConsolidatedSegment struct {
-	Speaker   string    `json:"speaker"`
-	Start     float64   `json:"start"`
-	End       float64   `json:"end"`
-	Text      string    `json:"text"`
-	Segments  []Segment `json:"segments"`
-	WordCount int       `json:"wordCount"`
+type LoadedTranscription struct {
+	FileName string
+	Data     *TranscriptionData
 }
 
-type Statistics struct {
-	SegmentCount  int     `json:"segmentCount"`
-	SpeakerCount  int     `json:"speakerCount"`
-	TotalDuration float64 `json:"totalDuration"`
-	WordCount     int     `json:"wordCount"`
+type SessionState struct {
+	FileName                 string             `json:"fileName"`
+	Transcription            *TranscriptionData `json:"transcription"`
+	RawText                  map[int]string     `json:"rawText,omitempty"`
+	SpeakerColors            map[string]string  `json:"speakerColors"`
+	ConsolidationThreshold   float64            `json:"consolidationThreshold"`
+	ConsolidateBySpeaker     bool               `json:"consolidateBySpeaker,omitempty"`
+	ConsolidationMaxDuration float64            `json:"consolidationMaxDuration,omitempty"`
+	ConsolidationMinWords    int                `json:"consolidationMinWords,omitempty"`
+	Bookmarks                []Bookmark         `json:"bookmarks,omitempty"`
+	IsConsolidated           bool               `json:"isConsolidated"`
+	SavedAt                  string             `json:"savedAt"`
 }
 
 type AudioData struct {
@@ -60,6 +139,94 @@ type AudioData struct {
 	FileBlob   js.Value `json:"-"`
 }
 
+const (
+	recentFilesKey           = "recentFiles"
+	recentFileCachePrefix    = "recentFile:"
+	maxRecentFiles           = 5
+	speakerColorsKey         = "speakerColors"
+	maxCachedTranscriptBytes = 512 * 1024
+	largeTranscriptMB        = 50.0
+	maxTranscriptMB          = 300.0
+	parseSegmentsPerChunk    = 2000
+	defaultMaxSegmentLength  = 1200
+	suspiciousSegmentLength  = 10000
+
+	defaultConsolidationThreshold = 10.0
+	minConsolidationThreshold     = 0.0
+	maxConsolidationThreshold     = 15.0
+	consolidationThresholdStep    = 0.5
+	defaultLowConfidence          = 0.6
+
+	maxUndoSteps = 50
+
+	defaultSubtitleMaxChars = 42
+	defaultSubtitleMaxLines = 2
+
+	activityBucketCount = 120
+	resizeDebounceMs    = 150
+	searchDebounceMs    = 200
+	searchTextSelector  = ".segment-text, .block-line-text"
+	tapMoveTolerancePx  = 10.0
+
+	peakSampleCount       = 4000
+	silentAmplitude       = 0.01
+	maxSilenceCheckRanges = 5
+	durationMismatchRatio = 0.1
+	minDurationMismatch   = 5.0
+	loudestWindowSeconds  = 1.0
+
+	sessionKey            = "session"
+	confirmToastTimeoutMs = 15000
+
+	defaultSilenceThreshold = 2.0
+
+	defaultZoom       = 2.0
+	maxZoom           = 200.0
+	maxWaveformPixels = 200000.0
+
+	virtualizeThreshold = 500
+	virtualRowHeight    = 96.0
+	virtualBufferRows   = 20
+
+	viewKey = "view"
+
+	volumeKey     = "volume"
+	defaultVolume = 1.0
+
+	durationPrecisionKey     = "durationPrecision"
+	defaultDurationPrecision = 1
+	maxDurationPrecision     = 3
+	showMillisecondsKey      = "showMilliseconds"
+	normalizeTextKey         = "normalizeText"
+	colorByConfidenceKey     = "colorByConfidence"
+	shortcutKeysKey          = "shortcutKeys"
+
+	maxRulerTicks = 10.0
+)
+
+var thresholdPreviewCandidates = []float64{1, 2, 5, 10, 15}
+
+var rulerIntervals = []float64{5, 10, 15, 30, 60, 120, 300, 600, 900, 1800, 3600}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+var speakerPalette = []string{
+	"#ef4444", "#f97316", "#eab308", "#22c55e",
+	"#06b6d4", "#3b82f6", "#8b5cf6", "#ec4899",
+	"#f59e0b", "#10b981", "#6366f1", "#d946ef",
+}
+
+var darkSpeakerPalette = []string{
+	"#f87171", "#fb923c", "#fde047", "#4ade80",
+	"#22d3ee", "#60a5fa", "#a78bfa", "#f472b6",
+	"#fcd34d", "#34d399", "#818cf8", "#e879f9",
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
 var app *AudioPipeApp
 
 func (app *AudioPipeApp) setupDragAndDrop() {
@@ -134,39 +301,162 @@ func (app *AudioPipeApp) highlightCurrentSpeaker() {
 			seg.Get("classList").Call("remove", "current-playing")
 		}
 	}
+
+	app.updateNowPlayingCaption()
+}
+
+func (app *AudioPipeApp) updateNowPlayingCaption() {
+	caption := js.Global().Get("document").Call("getElementById", "now-playing-caption")
+	if caption.IsNull() {
+		return
+	}
+
+	var segments []Segment
+	if app.transcriptionData != nil {
+		segments = app.transcriptionData.Segments
+	}
+	current := segmentIndexAt(segments, app.currentTime)
+	if current < 0 || app.currentTime > segments[current].End || len(segments[current].Words) == 0 {
+		text := ""
+		if current >= 0 && app.currentTime <= segments[current].End {
+			text = fmt.Sprintf("%s: %s", segments[current].Speaker, segments[current].Text)
+		}
+
+		app.captionKey = ""
+		if caption.Get("textContent").String() != text {
+			caption.Set("textContent", text)
+		}
+		return
+	}
+
+	segment := segments[current]
+	key := fmt.Sprintf("%.3f|%s", segment.Start, segment.Speaker)
+	if key != app.captionKey {
+		var htmlBuilder strings.Builder
+		htmlBuilder.WriteString(fmt.Sprintf(`<span class="caption-speaker">%s:</span>`, htmlEscape(segment.Speaker)))
+		for i, word := range segment.Words {
+			htmlBuilder.WriteString(fmt.Sprintf(` <span class="caption-word" data-word="%d">%s</span>`, i, htmlEscape(strings.TrimSpace(word.Word))))
+		}
+		caption.Set("innerHTML", htmlBuilder.String())
+		app.captionKey = key
+		app.captionWord = -1
+	}
+
+	word := activeWordIndex(segment.Words, app.currentTime)
+	if word == app.captionWord {
+		return
+	}
+
+	if app.captionWord >= 0 {
+		previous := caption.Call("querySelector", fmt.Sprintf(`[data-word="%d"]`, app.captionWord))
+		if !previous.IsNull() {
+			previous.Get("classList").Call("remove", "active-word")
+		}
+	}
+	if word >= 0 {
+		next := caption.Call("querySelector", fmt.Sprintf(`[data-word="%d"]`, word))
+		if !next.IsNull() {
+			next.Get("classList").Call("add", "active-word")
+		}
+	}
+	app.captionWord = word
 }
 
 func main() {
 	app = &AudioPipeApp{
 		currentView:            "timeline",
+		activeMatch:            -1,
+		captionWord:            -1,
+		pendingStartTime:       -1,
 		isDarkTheme:            false,
 		speakerColors:          make(map[string]string),
-		consolidationThreshold: 10.0,
+		selectedSegments:       make(map[int]bool),
+		soloedSpeakers:         make(map[string]bool),
+		mutedSpeakers:          make(map[string]bool),
+		maxSegmentLength:       defaultMaxSegmentLength,
+		lowConfidenceThreshold: defaultLowConfidence,
+		subtitleMaxChars:       defaultSubtitleMaxChars,
+		subtitleMaxLines:       defaultSubtitleMaxLines,
+		consolidationThreshold: defaultConsolidationThreshold,
+		durationPrecision:      defaultDurationPrecision,
+		volume:                 defaultVolume,
+		timelineRowHeight:      virtualRowHeight,
+		silenceThreshold:       defaultSilenceThreshold,
+		zoom:                   defaultZoom,
 		isConsolidated:         false,
 	}
 
 	app.initializeTheme()
+	app.readStartTimeParam()
 
 	js.Global().Set("loadTranscriptionFile", js.FuncOf(app.loadTranscriptionFile))
 	js.Global().Set("loadAudioFile", js.FuncOf(app.loadAudioFile))
+	js.Global().Set("loadTranscriptionFromURL", js.FuncOf(app.loadTranscriptionFromURL))
 	js.Global().Set("togglePlayback", js.FuncOf(app.togglePlayback))
 	js.Global().Set("seekAudio", js.FuncOf(app.seekAudio))
 	js.Global().Set("seekToTime", js.FuncOf(app.seekToTime))
+	js.Global().Set("playFromTime", js.FuncOf(app.playFromTime))
+	js.Global().Set("loopSegment", js.FuncOf(app.loopSegment))
+	js.Global().Set("nextSpeakerTurn", js.FuncOf(app.nextSpeakerTurn))
+	js.Global().Set("prevSpeakerTurn", js.FuncOf(app.prevSpeakerTurn))
+	js.Global().Set("seekToLoudest", js.FuncOf(app.seekToLoudest))
+	js.Global().Set("seekToNextOverlap", js.FuncOf(app.seekToNextOverlap))
+	js.Global().Set("copyPositionLink", js.FuncOf(app.copyPositionLink))
+	js.Global().Set("setVolume", js.FuncOf(app.setVolume))
+	js.Global().Set("setZoom", js.FuncOf(app.setZoom))
+	js.Global().Set("toggleMute", js.FuncOf(app.toggleMute))
 	js.Global().Set("toggleTheme", js.FuncOf(app.toggleTheme))
+	js.Global().Set("setSpeakerColor", js.FuncOf(app.setSpeakerColor))
 	js.Global().Set("handleSearch", js.FuncOf(app.handleSearch))
 	js.Global().Set("clearSearch", js.FuncOf(app.clearSearch))
+	js.Global().Set("searchNext", js.FuncOf(app.searchNext))
+	js.Global().Set("searchPrev", js.FuncOf(app.searchPrev))
 	js.Global().Set("exportAsText", js.FuncOf(app.exportAsText))
+	js.Global().Set("downloadAsText", js.FuncOf(app.downloadAsText))
+	js.Global().Set("copySegmentText", js.FuncOf(app.copySegmentText))
 	js.Global().Set("exportAsSRT", js.FuncOf(app.exportAsSRT))
+	js.Global().Set("exportAsVTT", js.FuncOf(app.exportAsVTT))
 	js.Global().Set("downloadConsolidated", js.FuncOf(app.downloadConsolidated))
+	js.Global().Set("downloadConsolidatedSRT", js.FuncOf(app.downloadConsolidatedSRT))
+	js.Global().Set("downloadConsolidatedVTT", js.FuncOf(app.downloadConsolidatedVTT))
+	js.Global().Set("exportAsJSON", js.FuncOf(app.exportAsJSON))
+	js.Global().Set("exportRange", js.FuncOf(app.exportRange))
+	js.Global().Set("addBookmark", js.FuncOf(app.addBookmark))
+	js.Global().Set("removeBookmark", js.FuncOf(app.removeBookmark))
+	js.Global().Set("exportAsHTML", js.FuncOf(app.exportAsHTML))
+	js.Global().Set("exportAsWord", js.FuncOf(app.exportAsWord))
+	js.Global().Set("exportAsMarkdown", js.FuncOf(app.exportAsMarkdown))
+	js.Global().Set("exportPerSpeakerZip", js.FuncOf(app.exportPerSpeakerZip))
+	js.Global().Set("exportSelected", js.FuncOf(app.exportSelected))
+	js.Global().Set("exportAsCSV", js.FuncOf(app.exportAsCSV))
+	js.Global().Set("exportSpeakerStatsCSV", js.FuncOf(app.exportSpeakerStatsCSV))
+	js.Global().Set("exportAsSubtitleSRT", js.FuncOf(app.exportAsSubtitleSRT))
+	js.Global().Set("exportLowConfidenceReport", js.FuncOf(app.exportLowConfidenceReport))
+	js.Global().Set("setLowConfidenceThreshold", js.FuncOf(app.setLowConfidenceThreshold))
+	js.Global().Set("toggleLowConfidenceOnly", js.FuncOf(app.toggleLowConfidenceOnly))
+	js.Global().Set("filterBySpeaker", js.FuncOf(app.filterBySpeaker))
+	js.Global().Set("selectTranscription", js.FuncOf(app.selectTranscription))
+	js.Global().Set("setMaxSegmentLength", js.FuncOf(app.setMaxSegmentLength))
 	js.Global().Set("showTimelineView", js.FuncOf(app.showTimelineView))
 	js.Global().Set("showVisualizationView", js.FuncOf(app.showVisualizationView))
 	js.Global().Set("consolidateSegments", js.FuncOf(app.consolidateSegments))
 	js.Global().Set("updateConsolidationThreshold", js.FuncOf(app.updateConsolidationThreshold))
 	js.Global().Set("applyConsolidation", js.FuncOf(app.applyConsolidation))
+	js.Global().Set("resetConsolidation", js.FuncOf(app.resetConsolidation))
+	js.Global().Set("setSegmentTiming", js.FuncOf(app.setSegmentTiming))
+	js.Global().Set("adjustSegmentTime", js.FuncOf(app.adjustSegmentTime))
+	js.Global().Set("mergeAdjacentSegments", js.FuncOf(app.mergeAdjacentSegments))
+	js.Global().Set("deleteSegment", js.FuncOf(app.deleteSegment))
+	js.Global().Set("reassignSegmentSpeaker", js.FuncOf(app.reassignSegmentSpeaker))
+	js.Global().Set("undoEdit", js.FuncOf(app.undoEdit))
+	js.Global().Set("openRecentFile", js.FuncOf(app.openRecentFile))
+	js.Global().Set("clearRecentFiles", js.FuncOf(app.clearRecentFiles))
+	js.Global().Set("clearSession", js.FuncOf(app.clearSession))
 
 	app.setupEventListeners()
 	app.showUploadState()
 	app.showToast("AudioPipe WASM Ready", "success")
+	app.offerSessionRestore()
 
 	select {}
 }
@@ -230,6 +520,11 @@ func (app *AudioPipeApp) setupEventListeners() {
 
 	searchInput := document.Call("getElementById", "search-input")
 	if !searchInput.IsNull() {
+		app.debouncedSearch = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.searchTimer = js.Undefined()
+			app.handleSearch(js.Value{}, []js.Value{searchInput.Get("value")})
+			return nil
+		})
 		searchInput.Call("addEventListener", "input", js.FuncOf(app.handleSearchInput))
 	}
 
@@ -238,249 +533,1877 @@ func (app *AudioPipeApp) setupEventListeners() {
 		clearSearchBtn.Call("addEventListener", "click", js.FuncOf(app.clearSearch))
 	}
 
+	undoBtn := document.Call("getElementById", "undo-edit")
+	if !undoBtn.IsNull() {
+		undoBtn.Call("addEventListener", "click", js.FuncOf(app.undoEdit))
+	}
+
+	searchNextBtn := document.Call("getElementById", "search-next")
+	if !searchNextBtn.IsNull() {
+		searchNextBtn.Call("addEventListener", "click", js.FuncOf(app.searchNext))
+	}
+
+	searchPrevBtn := document.Call("getElementById", "search-prev")
+	if !searchPrevBtn.IsNull() {
+		searchPrevBtn.Call("addEventListener", "click", js.FuncOf(app.searchPrev))
+	}
+
+	transcriptionContent := document.Call("getElementById", "transcription-content")
+	if !transcriptionContent.IsNull() {
+		transcriptionContent.Call("addEventListener", "change", js.FuncOf(app.handleSegmentSelection))
+		transcriptionContent.Call("addEventListener", "change", js.FuncOf(app.handleSpeakerReassign))
+		transcriptionContent.Call("addEventListener", "click", js.FuncOf(app.handleTimelineClick))
+	}
+
 	app.setupExportButtons()
 	app.setupViewButtons()
 	app.setupConsolidationControls()
+	app.setupRecentFiles()
+	app.setupSegmentPlayback()
+	app.setupKeyboardShortcuts()
+	app.setupResizeHandling()
+	app.setupDurationPrecision()
+	app.setupShowMilliseconds()
+	app.setupTextNormalization()
+	app.setupTranscriptionSelector()
+	app.setupVolumeControls()
+	app.setupZoomControls()
+	app.setupSilenceControls()
+	app.setupTimelineVirtualization()
+	app.setupBookmarks()
 	app.setupDragAndDrop()
 }
 
-func (app *AudioPipeApp) setupExportButtons() {
-	document := js.Global().Get("document")
+func (app *AudioPipeApp) setupResizeHandling() {
+	rerender := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		app.resizeTimer = js.Undefined()
+		if app.currentView == "visualization" && app.transcriptionData != nil {
+			app.renderSpeakingOrderStrip()
+			app.renderActivityStrip()
+			app.renderSpeakerTimelines()
+		}
+		return nil
+	})
 
-	exportText := document.Call("getElementById", "export-text")
-	if !exportText.IsNull() {
-		exportText.Call("addEventListener", "click", js.FuncOf(app.exportAsText))
-	}
+	onResize := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !app.resizeTimer.IsUndefined() {
+			js.Global().Call("clearTimeout", app.resizeTimer)
+		}
+		app.resizeTimer = js.Global().Call("setTimeout", rerender, resizeDebounceMs)
+		return nil
+	})
 
-	exportSRT := document.Call("getElementById", "export-srt")
-	if !exportSRT.IsNull() {
-		exportSRT.Call("addEventListener", "click", js.FuncOf(app.exportAsSRT))
+	visualizationContent := js.Global().Get("document").Call("getElementById", "visualization-content")
+	resizeObserver := js.Global().Get("ResizeObserver")
+	if !visualizationContent.IsNull() && !resizeObserver.IsUndefined() {
+		resizeObserver.New(onResize).Call("observe", visualizationContent)
+		return
 	}
 
-	exportConsolidated := document.Call("getElementById", "export-consolidated")
-	if !exportConsolidated.IsNull() {
-		exportConsolidated.Call("addEventListener", "click", js.FuncOf(app.downloadConsolidated))
-	}
+	js.Global().Call("addEventListener", "resize", onResize)
 }
 
-func (app *AudioPipeApp) setupViewButtons() {
+func (app *AudioPipeApp) setupKeyboardShortcuts() {
 	document := js.Global().Get("document")
 
-	viewTimeline := document.Call("getElementById", "view-timeline")
-	if !viewTimeline.IsNull() {
-		viewTimeline.Call("addEventListener", "click", js.FuncOf(app.showTimelineView))
+	prevTurnBtn := document.Call("getElementById", "prev-speaker-turn")
+	if !prevTurnBtn.IsNull() {
+		prevTurnBtn.Call("addEventListener", "click", js.FuncOf(app.prevSpeakerTurn))
 	}
 
-	viewSpeakers := document.Call("getElementById", "view-speakers")
-	if !viewSpeakers.IsNull() {
-		viewSpeakers.Call("addEventListener", "click", js.FuncOf(app.showTimelineView)) // Same as timeline for now
+	nextTurnBtn := document.Call("getElementById", "next-speaker-turn")
+	if !nextTurnBtn.IsNull() {
+		nextTurnBtn.Call("addEventListener", "click", js.FuncOf(app.nextSpeakerTurn))
 	}
 
-	viewVisualization := document.Call("getElementById", "view-visualization")
-	if !viewVisualization.IsNull() {
-		viewVisualization.Call("addEventListener", "click", js.FuncOf(app.showVisualizationView))
+	overlapBtn := document.Call("getElementById", "next-overlap")
+	if !overlapBtn.IsNull() {
+		overlapBtn.Call("addEventListener", "click", js.FuncOf(app.seekToNextOverlap))
 	}
-}
 
-func (app *AudioPipeApp) setupConsolidationControls() {
-	document := js.Global().Get("document")
+	loudestBtn := document.Call("getElementById", "seek-loudest")
+	if !loudestBtn.IsNull() {
+		loudestBtn.Call("addEventListener", "click", js.FuncOf(app.seekToLoudest))
+	}
 
-	thresholdSlider := document.Call("getElementById", "consolidation-threshold")
-	if !thresholdSlider.IsNull() {
-		thresholdSlider.Call("addEventListener", "input", js.FuncOf(app.updateConsolidationThreshold))
+	shareBtn := document.Call("getElementById", "share-position")
+	if !shareBtn.IsNull() {
+		shareBtn.Call("addEventListener", "click", js.FuncOf(app.copyPositionLink))
 	}
 
-	applyBtn := document.Call("getElementById", "apply-consolidation")
-	if !applyBtn.IsNull() {
-		applyBtn.Call("addEventListener", "click", js.FuncOf(app.applyConsolidation))
+	app.shortcutOverrides = app.loadShortcutOverrides()
+	document.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return nil
+		}
+
+		event := args[0]
+		if event.Get("ctrlKey").Bool() || event.Get("metaKey").Bool() || event.Get("altKey").Bool() {
+			return nil
+		}
+
+		target := event.Get("target")
+		tagName := strings.ToLower(target.Get("tagName").String())
+		if tagName == "input" || tagName == "textarea" || tagName == "select" || target.Get("isContentEditable").Bool() {
+			return nil
+		}
+
+		key := event.Get("key").String()
+		if key == "Escape" && app.shortcutsHelpVisible() {
+			app.toggleShortcutsHelp()
+			event.Call("preventDefault")
+			return nil
+		}
+
+		for _, shortcut := range app.keyboardShortcuts() {
+			for _, shortcutKey := range shortcut.Keys {
+				if key == shortcutKey {
+					shortcut.Run()
+					event.Call("preventDefault")
+					return nil
+				}
+			}
+		}
+		return nil
+	}))
+
+	shortcutsOverlay := document.Call("getElementById", "shortcuts-overlay")
+	if !shortcutsOverlay.IsNull() {
+		shortcutsOverlay.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			target := args[0].Get("target")
+			if target.Equal(shortcutsOverlay) || !target.Call("closest", ".shortcuts-close").IsNull() {
+				app.toggleShortcutsHelp()
+			}
+			return nil
+		}))
 	}
 }
 
-func (app *AudioPipeApp) preventDefaults(this js.Value, args []js.Value) interface{} {
-	if len(args) > 0 {
-		args[0].Call("preventDefault")
-		args[0].Call("stopPropagation")
+func (app *AudioPipeApp) keyboardShortcuts() []KeyboardShortcut {
+	shortcuts := []KeyboardShortcut{
+		{"prev-turn", []string{"["}, "[", "Previous speaker turn", func() { app.prevSpeakerTurn(js.Value{}, []js.Value{}) }},
+		{"next-turn", []string{"]"}, "]", "Next speaker turn", func() { app.nextSpeakerTurn(js.Value{}, []js.Value{}) }},
+		{"bookmark", []string{"b", "B"}, "B", "Bookmark the current time", func() { app.addBookmark(js.Value{}, []js.Value{}) }},
+		{"theme", []string{"t", "T"}, "T", "Toggle dark/light theme", func() { app.toggleTheme(js.Value{}, []js.Value{}) }},
+		{"timeline-view", []string{"1"}, "1", "Timeline view", func() { app.showTimelineView(js.Value{}, []js.Value{}) }},
+		{"visual-view", []string{"2"}, "2", "Visual view", func() { app.showVisualizationView(js.Value{}, []js.Value{}) }},
+		{"search", []string{"/"}, "/", "Focus the search box", app.focusSearch},
+		{"help", []string{"?"}, "?", "Show or hide this help", app.toggleShortcutsHelp},
 	}
-	return nil
-}
 
-func (app *AudioPipeApp) handleFileInput(this js.Value, args []js.Value) interface{} {
-	if len(args) > 0 {
-		files := args[0].Get("target").Get("files")
-		if files.Length() > 0 {
-			app.processFile(files.Index(0))
+	for i, shortcut := range shortcuts {
+		override, ok := app.shortcutOverrides[shortcut.Action]
+		if !ok {
+			continue
+		}
+		if keys, label, valid := shortcutBinding(override); valid {
+			shortcuts[i].Keys, shortcuts[i].Label = keys, label
 		}
 	}
-	return nil
+	return shortcuts
 }
 
-func (app *AudioPipeApp) handleFileDrop(this js.Value, args []js.Value) interface{} {
-	log.Printf("File drop event triggered")
+func (app *AudioPipeApp) loadShortcutOverrides() map[string]string {
+	overrides := make(map[string]string)
+	saved := js.Global().Get("localStorage").Call("getItem", shortcutKeysKey)
+	if saved.IsNull() {
+		return overrides
+	}
 
-	if len(args) == 0 {
-		log.Printf("No arguments in drop event")
-		return nil
+	if err := json.Unmarshal([]byte(saved.String()), &overrides); err != nil {
+		log.Printf("Discarding unreadable shortcut keys: %v", err)
+		return make(map[string]string)
 	}
 
-	event := args[0]
-	dataTransfer := event.Get("dataTransfer")
-	if dataTransfer.IsUndefined() {
-		log.Printf("No dataTransfer in drop event")
-		return nil
+	for action, key := range overrides {
+		if _, _, valid := shortcutBinding(key); !valid {
+			log.Printf("Ignoring shortcut key %q for %s: use a single character", key, action)
+		}
 	}
+	return overrides
+}
 
-	files := dataTransfer.Get("files")
-	if files.IsUndefined() {
-		log.Printf("No files in dataTransfer")
-		return nil
+func (app *AudioPipeApp) focusSearch() {
+	searchInput := js.Global().Get("document").Call("getElementById", "search-input")
+	if searchInput.IsNull() {
+		return
 	}
+	searchInput.Call("focus")
+	searchInput.Call("select")
+}
 
-	fileCount := files.Length()
-	log.Printf("Dropped %d files", fileCount)
+func (app *AudioPipeApp) shortcutsHelpVisible() bool {
+	overlay := js.Global().Get("document").Call("getElementById", "shortcuts-overlay")
+	return !overlay.IsNull() && overlay.Get("style").Get("display").String() != "none"
+}
 
-	if fileCount > 0 {
-		file := files.Index(0)
-		fileName := file.Get("name").String()
-		fileType := file.Get("type").String()
-		log.Printf("Processing dropped file: %s (type: %s)", fileName, fileType)
-		app.processFile(file)
-	} else {
-		log.Printf("No files to process")
+func (app *AudioPipeApp) toggleShortcutsHelp() {
+	overlay := js.Global().Get("document").Call("getElementById", "shortcuts-overlay")
+	if overlay.IsNull() {
+		return
 	}
 
-	return nil
-}
+	if app.shortcutsHelpVisible() {
+		overlay.Get("style").Set("display", "none")
+		return
+	}
 
-func (app *AudioPipeApp) loadTranscriptionFile(this js.Value, args []js.Value) interface{} {
-	if len(args) > 0 {
-		app.processFile(args[0])
+	var htmlBuilder strings.Builder
+	for _, shortcut := range app.keyboardShortcuts() {
+		htmlBuilder.WriteString(fmt.Sprintf(`<div class="shortcut-row"><kbd>%s</kbd><span>%s</span></div>`,
+			htmlEscape(shortcut.Label), htmlEscape(shortcut.Description)))
 	}
-	return nil
+
+	overlay.Set("innerHTML", fmt.Sprintf(`
+		<div class="shortcuts-panel" role="dialog" aria-label="Keyboard shortcuts">
+			<div class="shortcuts-header">
+				<span><i class="fas fa-keyboard"></i> KEYBOARD SHORTCUTS</span>
+				<button class="shortcuts-close clear-btn" title="Close (Esc)"><i class="fas fa-times"></i></button>
+			</div>
+			%s
+		</div>
+	`, htmlBuilder.String()))
+	overlay.Get("style").Set("display", "flex")
 }
 
-func (app *AudioPipeApp) processFile(file js.Value) {
-	fileName := file.Get("name").String()
-	fileType := file.Get("type").String()
+func (app *AudioPipeApp) setupDurationPrecision() {
+	saved := js.Global().Get("localStorage").Call("getItem", durationPrecisionKey)
+	if !saved.IsNull() {
+		if precision, err := strconv.Atoi(saved.String()); err == nil && precision >= 0 && precision <= maxDurationPrecision {
+			app.durationPrecision = precision
+		}
+	}
 
-	if strings.HasPrefix(fileType, "audio/") {
-		app.processAudioFile(file)
+	precisionSelect := js.Global().Get("document").Call("getElementById", "duration-precision")
+	if precisionSelect.IsNull() {
 		return
 	}
 
-	app.showLoadingState("Processing " + fileName + "...")
-
-	reader := js.Global().Get("FileReader").New()
+	precisionSelect.Set("value", strconv.Itoa(app.durationPrecision))
+	precisionSelect.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		precision, err := strconv.Atoi(precisionSelect.Get("value").String())
+		if err != nil || precision < 0 || precision > maxDurationPrecision {
+			precision = defaultDurationPrecision
+		}
 
-	reader.Set("onload", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		result := args[0].Get("target").Get("result").String()
-		app.parseTranscriptionData(result, fileName)
-		return nil
-	}))
+		app.durationPrecision = precision
+		js.Global().Get("localStorage").Call("setItem", durationPrecisionKey, strconv.Itoa(precision))
 
-	reader.Set("onerror", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		app.showToast("Failed to read file", "error")
-		app.showUploadState()
+		if app.transcriptionData != nil {
+			app.updateStatistics()
+			app.refreshCurrentView()
+		}
 		return nil
 	}))
-
-	reader.Call("readAsText", file)
 }
 
-func (app *AudioPipeApp) parseTranscriptionData(jsonData, fileName string) {
-	var transcriptionData TranscriptionData
+func (app *AudioPipeApp) setupShowMilliseconds() {
+	localStorage := js.Global().Get("localStorage")
+	app.showMilliseconds = localStorage.Call("getItem", showMillisecondsKey).String() == "true"
 
-	err := json.Unmarshal([]byte(jsonData), &transcriptionData)
-	if err != nil {
-		app.showToast("Invalid JSON format", "error")
-		app.showUploadState()
+	toggle := js.Global().Get("document").Call("getElementById", "show-ms")
+	if toggle.IsNull() {
 		return
 	}
 
-	if len(transcriptionData.Segments) == 0 {
-		app.showToast("No segments found in transcription", "warning")
-		app.showUploadState()
+	toggle.Set("checked", app.showMilliseconds)
+	toggle.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		app.showMilliseconds = toggle.Get("checked").Bool()
+		localStorage.Call("setItem", showMillisecondsKey, strconv.FormatBool(app.showMilliseconds))
+		if app.transcriptionData != nil {
+			app.refreshCurrentView()
+		}
+		return nil
+	}))
+}
+
+func (app *AudioPipeApp) setupTextNormalization() {
+	localStorage := js.Global().Get("localStorage")
+	app.normalizeImportedText = localStorage.Call("getItem", normalizeTextKey).String() != "false"
+
+	toggle := js.Global().Get("document").Call("getElementById", "normalize-text")
+	if toggle.IsNull() {
 		return
 	}
 
-	app.transcriptionData = &transcriptionData
+	toggle.Set("checked", app.normalizeImportedText)
+	toggle.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		app.normalizeImportedText = toggle.Get("checked").Bool()
+		localStorage.Call("setItem", normalizeTextKey, strconv.FormatBool(app.normalizeImportedText))
 
-	app.calculateStatistics()
-	app.generateSpeakerColors()
-	app.updateStatistics()
-	app.showToast(fmt.Sprintf("Loaded %s with %d segments", fileName, len(transcriptionData.Segments)), "success")
+		changed := 0
+		for _, loaded := range app.transcriptions {
+			if app.normalizeImportedText {
+				changed += normalizeSegments(loaded.Data.Segments)
+			} else {
+				changed += restoreRawText(loaded.Data.Segments)
+			}
+		}
 
-	app.showTimelineView(js.Value{}, []js.Value{})
+		if changed > 0 && app.transcriptionData != nil {
+			app.afterSegmentsChanged()
+		}
+		if app.normalizeImportedText {
+			app.showToast(fmt.Sprintf("Normalized text in %d segment(s)", changed), "info")
+		} else {
+			app.showToast(fmt.Sprintf("Restored original text in %d segment(s)", changed), "info")
+		}
+		return nil
+	}))
 }
 
-func (app *AudioPipeApp) calculateStatistics() {
-	if app.transcriptionData == nil {
+func (app *AudioPipeApp) setupSilenceControls() {
+	silenceInput := js.Global().Get("document").Call("getElementById", "silence-threshold")
+	if silenceInput.IsNull() {
 		return
 	}
 
-	segments := app.transcriptionData.Segments
-	speakerMap := make(map[string]bool)
-	totalWords := 0
-	maxEnd := 0.0
+	silenceInput.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		threshold, err := strconv.ParseFloat(silenceInput.Get("value").String(), 64)
+		if err != nil || threshold < 0 {
+			threshold = defaultSilenceThreshold
+			silenceInput.Set("value", threshold)
+		}
+		app.silenceThreshold = threshold
 
-	for _, segment := range segments {
-		speakerMap[segment.Speaker] = true
-		words := len(strings.Fields(segment.Text))
-		totalWords += words
+		if app.transcriptionData != nil {
+			app.calculateStatistics()
+			app.updateStatistics()
+			app.refreshCurrentView()
+		}
+		return nil
+	}))
+}
+
+func (app *AudioPipeApp) setupVolumeControls() {
+	document := js.Global().Get("document")
 
-		if segment.End > maxEnd {
-			maxEnd = segment.End
+	saved := js.Global().Get("localStorage").Call("getItem", volumeKey)
+	if !saved.IsNull() {
+		if volume, err := strconv.ParseFloat(saved.String(), 64); err == nil {
+			app.volume = math.Max(0, math.Min(1, volume))
 		}
 	}
 
-	app.statistics = Statistics{
-		SegmentCount:  len(segments),
-		SpeakerCount:  len(speakerMap),
-		TotalDuration: maxEnd,
-		WordCount:     totalWords,
+	volumeSlider := document.Call("getElementById", "volume-slider")
+	if !volumeSlider.IsNull() {
+		volumeSlider.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			volume, err := strconv.ParseFloat(volumeSlider.Get("value").String(), 64)
+			if err != nil {
+				return nil
+			}
+			app.applyVolume(volume)
+			return nil
+		}))
+	}
+
+	muteBtn := document.Call("getElementById", "mute-toggle")
+	if !muteBtn.IsNull() {
+		muteBtn.Call("addEventListener", "click", js.FuncOf(app.toggleMute))
 	}
+
+	app.updateVolumeControls()
 }
 
-func (app *AudioPipeApp) generateSpeakerColors() {
-	if app.transcriptionData == nil {
+func (app *AudioPipeApp) setupZoomControls() {
+	zoomSlider := js.Global().Get("document").Call("getElementById", "waveform-zoom")
+	if zoomSlider.IsNull() {
 		return
 	}
 
-	colors := []string{
-		"#ef4444", "#f97316", "#eab308", "#22c55e",
-		"#06b6d4", "#3b82f6", "#8b5cf6", "#ec4899",
-		"#f59e0b", "#10b981", "#6366f1", "#d946ef",
-	}
+	zoomSlider.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		zoom, err := strconv.ParseFloat(zoomSlider.Get("value").String(), 64)
+		if err != nil {
+			return nil
+		}
+		return app.setZoom(js.Value{}, []js.Value{js.ValueOf(zoom)})
+	}))
+}
 
-	speakers := app.getUniqueSpeakers()
-	for i, speaker := range speakers {
-		app.speakerColors[speaker] = colors[i%len(colors)]
+func (app *AudioPipeApp) setZoom(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || args[0].Type() != js.TypeNumber {
+		return nil
 	}
+
+	app.zoom = math.Max(1, math.Min(maxZoom, args[0].Float()))
+	app.applyZoom()
+	return nil
 }
 
-func (app *AudioPipeApp) getUniqueSpeakers() []string {
-	if app.transcriptionData == nil {
-		return []string{}
+func (app *AudioPipeApp) applyZoom() {
+	zoomSlider := js.Global().Get("document").Call("getElementById", "waveform-zoom")
+	if !zoomSlider.IsNull() {
+		zoomSlider.Set("value", app.zoom)
 	}
 
-	speakerMap := make(map[string]bool)
-	for _, segment := range app.transcriptionData.Segments {
-		speakerMap[segment.Speaker] = true
+	if app.audioData == nil || app.audioData.WaveSurfer.IsUndefined() || app.audioData.Duration <= 0 {
+		return
 	}
 
-	speakers := make([]string, 0, len(speakerMap))
-	for speaker := range speakerMap {
-		speakers = append(speakers, speaker)
+	pxPerSec := app.zoom
+	if limit := maxWaveformPixels / app.audioData.Duration; pxPerSec > limit {
+		pxPerSec = math.Max(1, limit)
+		log.Printf("🔍 ZOOM CAPPED: %.1f px/s requested, using %.1f px/s for %.0fs of audio", app.zoom, pxPerSec, app.audioData.Duration)
 	}
 
-	sort.Strings(speakers)
-	return speakers
+	app.audioData.WaveSurfer.Call("zoom", pxPerSec)
 }
 
-func (app *AudioPipeApp) hideAllStates() {
-	document := js.Global().Get("document")
-	sections := []string{
-		"welcome-state", "loading-state", "error-state", "no-results-state",
-		"transcription-content", "visualization-content",
+func (app *AudioPipeApp) setVolume(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || args[0].Type() != js.TypeNumber {
+		return nil
+	}
+
+	app.applyVolume(args[0].Float())
+	return nil
+}
+
+func (app *AudioPipeApp) toggleMute(this js.Value, args []js.Value) interface{} {
+	if app.volume > 0 {
+		app.preMuteVolume = app.volume
+		app.applyVolume(0)
+		return nil
+	}
+
+	restore := app.preMuteVolume
+	if restore <= 0 {
+		restore = defaultVolume
+	}
+	app.applyVolume(restore)
+	return nil
+}
+
+func (app *AudioPipeApp) applyVolume(volume float64) {
+	app.volume = math.Max(0, math.Min(1, volume))
+	js.Global().Get("localStorage").Call("setItem", volumeKey, strconv.FormatFloat(app.volume, 'f', 2, 64))
+
+	if app.audioData != nil && !app.audioData.WaveSurfer.IsUndefined() {
+		app.audioData.WaveSurfer.Call("setVolume", app.volume)
+	}
+
+	app.updateVolumeControls()
+}
+
+func (app *AudioPipeApp) updateVolumeControls() {
+	document := js.Global().Get("document")
+
+	volumeSlider := document.Call("getElementById", "volume-slider")
+	if !volumeSlider.IsNull() {
+		volumeSlider.Set("value", app.volume)
+	}
+
+	muteIcon := document.Call("querySelector", "#mute-toggle i")
+	if !muteIcon.IsNull() {
+		if app.volume == 0 {
+			muteIcon.Set("className", "fas fa-volume-mute")
+		} else {
+			muteIcon.Set("className", "fas fa-volume-up")
+		}
+	}
+}
+
+func (app *AudioPipeApp) setupSegmentPlayback() {
+	document := js.Global().Get("document")
+	localStorage := js.Global().Get("localStorage")
+
+	app.clickToPlay = localStorage.Call("getItem", "clickToPlay").String() == "true"
+
+	clickToPlayToggle := document.Call("getElementById", "click-to-play")
+	if !clickToPlayToggle.IsNull() {
+		clickToPlayToggle.Set("checked", app.clickToPlay)
+		clickToPlayToggle.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.clickToPlay = clickToPlayToggle.Get("checked").Bool()
+			localStorage.Call("setItem", "clickToPlay", strconv.FormatBool(app.clickToPlay))
+			return nil
+		}))
+	}
+
+	app.skipSilence = localStorage.Call("getItem", "skipSilence").String() == "true"
+
+	skipSilenceToggle := document.Call("getElementById", "skip-silence")
+	if !skipSilenceToggle.IsNull() {
+		skipSilenceToggle.Set("checked", app.skipSilence)
+		skipSilenceToggle.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.skipSilence = skipSilenceToggle.Get("checked").Bool()
+			localStorage.Call("setItem", "skipSilence", strconv.FormatBool(app.skipSilence))
+			app.noteManualSeek(app.currentTime)
+			return nil
+		}))
+	}
+
+	app.scaleToAudio = localStorage.Call("getItem", "scaleToAudio").String() == "true"
+
+	scaleToAudioToggle := document.Call("getElementById", "scale-to-audio")
+	if !scaleToAudioToggle.IsNull() {
+		scaleToAudioToggle.Set("checked", app.scaleToAudio)
+		scaleToAudioToggle.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.scaleToAudio = scaleToAudioToggle.Get("checked").Bool()
+			localStorage.Call("setItem", "scaleToAudio", strconv.FormatBool(app.scaleToAudio))
+			if app.currentView == "visualization" {
+				app.refreshCurrentView()
+			}
+			return nil
+		}))
+	}
+
+	speakerWaveforms := document.Call("getElementById", "speaker-waveforms")
+	if !speakerWaveforms.IsNull() {
+		activateTrackPoint := func(target js.Value, clientX float64) {
+			trackAction := target.Call("closest", "[data-track-action]")
+			if !trackAction.IsNull() {
+				app.toggleTrackState(trackAction.Call("getAttribute", "data-track-action").String(),
+					trackAction.Call("getAttribute", "data-speaker").String())
+				return
+			}
+
+			bar := target.Call("closest", ".speaker-segment-bar")
+			if !bar.IsNull() {
+				app.activateSegmentElement(bar)
+				return
+			}
+
+			track := target.Call("closest", ".clean-speaker-timeline")
+			if !track.IsNull() {
+				app.seekToPointer(track, clientX)
+			}
+		}
+
+		speakerWaveforms.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) > 0 {
+				activateTrackPoint(args[0].Get("target"), args[0].Get("clientX").Float())
+			}
+			return nil
+		}))
+		app.addTapListener(speakerWaveforms, activateTrackPoint)
+	}
+
+	timelineRuler := document.Call("getElementById", "timeline-ruler")
+	if !timelineRuler.IsNull() {
+		seekOnRuler := func(target js.Value, clientX float64) {
+			app.seekToPointer(timelineRuler, clientX)
+		}
+
+		timelineRuler.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) > 0 {
+				seekOnRuler(args[0].Get("target"), args[0].Get("clientX").Float())
+			}
+			return nil
+		}))
+		app.addTapListener(timelineRuler, seekOnRuler)
+	}
+
+	speakerStats := document.Call("getElementById", "speaker-stats")
+	if !speakerStats.IsNull() {
+		speakerStats.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			input := args[0].Get("target")
+			if !input.Get("classList").Call("contains", "speaker-color-input").Bool() {
+				return nil
+			}
+			app.setSpeakerColor(js.Value{}, []js.Value{input.Call("getAttribute", "data-speaker"), input.Get("value")})
+			return nil
+		}))
+	}
+
+	orderStrip := document.Call("getElementById", "speaking-order-strip")
+	if !orderStrip.IsNull() {
+		orderStrip.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			turn := args[0].Get("target").Call("closest", ".speaking-turn")
+			if !turn.IsNull() {
+				app.activateSegmentElement(turn)
+			}
+			return nil
+		}))
+	}
+
+	activityStrip := document.Call("getElementById", "activity-strip")
+	if !activityStrip.IsNull() {
+		activityStrip.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			bucket := args[0].Get("target").Call("closest", ".activity-bucket")
+			if !bucket.IsNull() {
+				app.activateSegmentElement(bucket)
+			}
+			return nil
+		}))
+	}
+
+	contextMenu := document.Call("getElementById", "segment-context-menu")
+	if contextMenu.IsNull() {
+		return
+	}
+
+	document.Call("addEventListener", "contextmenu", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 || app.audioData == nil {
+			return nil
+		}
+
+		event := args[0]
+		target := event.Get("target").Call("closest", ".timeline-segment-item, .speaker-segment-bar")
+		if target.IsNull() {
+			return nil
+		}
+
+		start, err := strconv.ParseFloat(target.Call("getAttribute", "data-start").String(), 64)
+		if err != nil {
+			return nil
+		}
+
+		event.Call("preventDefault")
+		app.contextMenuTime = start
+		style := contextMenu.Get("style")
+		style.Set("left", fmt.Sprintf("%dpx", event.Get("clientX").Int()))
+		style.Set("top", fmt.Sprintf("%dpx", event.Get("clientY").Int()))
+		style.Set("display", "block")
+		return nil
+	}))
+
+	contextMenu.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return nil
+		}
+		item := args[0].Get("target").Call("closest", "[data-menu-action]")
+		if item.IsNull() {
+			return nil
+		}
+
+		switch item.Call("getAttribute", "data-menu-action").String() {
+		case "play":
+			app.playFromTime(js.Value{}, []js.Value{js.ValueOf(app.contextMenuTime)})
+		case "seek":
+			app.seekToTime(js.Value{}, []js.Value{js.ValueOf(app.contextMenuTime)})
+		case "bookmark":
+			app.insertBookmark(app.contextMenuTime, "")
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		contextMenu.Get("style").Set("display", "none")
+		return nil
+	}))
+}
+
+func (app *AudioPipeApp) setupExportButtons() {
+	document := js.Global().Get("document")
+
+	exportText := document.Call("getElementById", "export-text")
+	if !exportText.IsNull() {
+		exportText.Call("addEventListener", "click", js.FuncOf(app.exportAsText))
+	}
+
+	exportTXT := document.Call("getElementById", "export-txt")
+	if !exportTXT.IsNull() {
+		exportTXT.Call("addEventListener", "click", js.FuncOf(app.downloadAsText))
+	}
+
+	exportSRT := document.Call("getElementById", "export-srt")
+	if !exportSRT.IsNull() {
+		exportSRT.Call("addEventListener", "click", js.FuncOf(app.exportAsSRT))
+	}
+
+	exportVTT := document.Call("getElementById", "export-vtt")
+	if !exportVTT.IsNull() {
+		exportVTT.Call("addEventListener", "click", js.FuncOf(app.exportAsVTT))
+	}
+
+	exportConsolidated := document.Call("getElementById", "export-consolidated")
+	if !exportConsolidated.IsNull() {
+		exportConsolidated.Call("addEventListener", "click", js.FuncOf(app.downloadConsolidated))
+	}
+
+	exportConsolidatedSRT := document.Call("getElementById", "export-consolidated-srt")
+	if !exportConsolidatedSRT.IsNull() {
+		exportConsolidatedSRT.Call("addEventListener", "click", js.FuncOf(app.downloadConsolidatedSRT))
+	}
+
+	exportConsolidatedVTT := document.Call("getElementById", "export-consolidated-vtt")
+	if !exportConsolidatedVTT.IsNull() {
+		exportConsolidatedVTT.Call("addEventListener", "click", js.FuncOf(app.downloadConsolidatedVTT))
+	}
+
+	exportJSON := document.Call("getElementById", "export-json")
+	if !exportJSON.IsNull() {
+		exportJSON.Call("addEventListener", "click", js.FuncOf(app.exportAsJSON))
+	}
+
+	exportHTML := document.Call("getElementById", "export-html")
+	if !exportHTML.IsNull() {
+		exportHTML.Call("addEventListener", "click", js.FuncOf(app.exportAsHTML))
+	}
+
+	exportWord := document.Call("getElementById", "export-word")
+	if !exportWord.IsNull() {
+		exportWord.Call("addEventListener", "click", js.FuncOf(app.exportAsWord))
+	}
+
+	exportMarkdown := document.Call("getElementById", "export-markdown")
+	if !exportMarkdown.IsNull() {
+		exportMarkdown.Call("addEventListener", "click", js.FuncOf(app.exportAsMarkdown))
+	}
+
+	exportSpeakerZip := document.Call("getElementById", "export-speaker-zip")
+	if !exportSpeakerZip.IsNull() {
+		exportSpeakerZip.Call("addEventListener", "click", js.FuncOf(app.exportPerSpeakerZip))
+	}
+
+	exportSelected := document.Call("getElementById", "export-selected")
+	if !exportSelected.IsNull() {
+		exportSelected.Call("addEventListener", "click", js.FuncOf(app.exportSelected))
+	}
+
+	exportRangeBtn := document.Call("getElementById", "export-range")
+	if !exportRangeBtn.IsNull() {
+		exportRangeBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			start, errStart := strconv.ParseFloat(document.Call("getElementById", "range-start").Get("value").String(), 64)
+			end, errEnd := strconv.ParseFloat(document.Call("getElementById", "range-end").Get("value").String(), 64)
+			if errStart != nil || errEnd != nil {
+				app.showToast("Enter a start and end time in seconds", "warning")
+				return nil
+			}
+			format := document.Call("getElementById", "export-range-format").Get("value").String()
+			return app.exportRange(js.Value{}, []js.Value{js.ValueOf(start), js.ValueOf(end), js.ValueOf(format)})
+		}))
+	}
+
+	exportSubtitleSRT := document.Call("getElementById", "export-subtitle-srt")
+	if !exportSubtitleSRT.IsNull() {
+		exportSubtitleSRT.Call("addEventListener", "click", js.FuncOf(app.exportAsSubtitleSRT))
+	}
+
+	exportCSV := document.Call("getElementById", "export-csv")
+	if !exportCSV.IsNull() {
+		exportCSV.Call("addEventListener", "click", js.FuncOf(app.exportAsCSV))
+	}
+
+	exportSpeakerStats := document.Call("getElementById", "export-speaker-stats")
+	if !exportSpeakerStats.IsNull() {
+		exportSpeakerStats.Call("addEventListener", "click", js.FuncOf(app.exportSpeakerStatsCSV))
+	}
+
+	exportLowConfidence := document.Call("getElementById", "export-low-confidence")
+	if !exportLowConfidence.IsNull() {
+		exportLowConfidence.Call("addEventListener", "click", js.FuncOf(app.exportLowConfidenceReport))
+	}
+
+	clearSpeakerFilter := document.Call("getElementById", "clear-speaker-filter")
+	if !clearSpeakerFilter.IsNull() {
+		clearSpeakerFilter.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return app.filterBySpeaker(js.Value{}, []js.Value{js.ValueOf(app.speakerFilter)})
+		}))
+	}
+
+	speakerLegend := document.Call("getElementById", "speaker-legend")
+	if !speakerLegend.IsNull() {
+		speakerLegend.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			item := args[0].Get("target").Call("closest", "[data-speaker]")
+			if item.IsNull() {
+				return nil
+			}
+			return app.filterBySpeaker(js.Value{}, []js.Value{item.Call("getAttribute", "data-speaker")})
+		}))
+	}
+
+	lowConfidenceOnly := document.Call("getElementById", "low-confidence-only")
+	if !lowConfidenceOnly.IsNull() {
+		lowConfidenceOnly.Call("addEventListener", "click", js.FuncOf(app.toggleLowConfidenceOnly))
+	}
+
+	localStorage := js.Global().Get("localStorage")
+	app.colorByConfidence = localStorage.Call("getItem", colorByConfidenceKey).String() == "true"
+
+	colorModeToggle := document.Call("getElementById", "color-by-confidence")
+	if !colorModeToggle.IsNull() {
+		colorModeToggle.Set("checked", app.colorByConfidence)
+		colorModeToggle.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.colorByConfidence = colorModeToggle.Get("checked").Bool()
+			localStorage.Call("setItem", colorByConfidenceKey, strconv.FormatBool(app.colorByConfidence))
+			if app.transcriptionData != nil {
+				app.refreshCurrentView()
+			}
+			return nil
+		}))
+	}
+
+	lowConfidenceInput := document.Call("getElementById", "low-confidence-threshold")
+	if !lowConfidenceInput.IsNull() {
+		lowConfidenceInput.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) > 0 {
+				value, err := strconv.ParseFloat(args[0].Get("target").Get("value").String(), 64)
+				if err != nil {
+					log.Printf("Error parsing low confidence threshold: %v", err)
+					return nil
+				}
+				app.setLowConfidenceThreshold(js.Value{}, []js.Value{js.ValueOf(value)})
+			}
+			return nil
+		}))
+	}
+}
+
+func (app *AudioPipeApp) setupViewButtons() {
+	document := js.Global().Get("document")
+
+	viewTimeline := document.Call("getElementById", "view-timeline")
+	if !viewTimeline.IsNull() {
+		viewTimeline.Call("addEventListener", "click", js.FuncOf(app.showTimelineView))
+	}
+
+	viewSpeakers := document.Call("getElementById", "view-speakers")
+	if !viewSpeakers.IsNull() {
+		viewSpeakers.Call("addEventListener", "click", js.FuncOf(app.showTimelineView)) // Same as timeline for now
+	}
+
+	viewVisualization := document.Call("getElementById", "view-visualization")
+	if !viewVisualization.IsNull() {
+		viewVisualization.Call("addEventListener", "click", js.FuncOf(app.showVisualizationView))
+	}
+}
+
+func (app *AudioPipeApp) setupConsolidationControls() {
+	document := js.Global().Get("document")
+
+	thresholdSlider := document.Call("getElementById", "consolidation-threshold")
+	if !thresholdSlider.IsNull() {
+		thresholdSlider.Set("min", minConsolidationThreshold)
+		thresholdSlider.Set("max", maxConsolidationThreshold)
+		thresholdSlider.Set("step", consolidationThresholdStep)
+		thresholdSlider.Call("addEventListener", "input", js.FuncOf(app.updateConsolidationThreshold))
+	}
+
+	thresholdInput := document.Call("getElementById", "threshold-input")
+	if !thresholdInput.IsNull() {
+		thresholdInput.Set("min", minConsolidationThreshold)
+		thresholdInput.Set("max", maxConsolidationThreshold)
+		thresholdInput.Set("title", fmt.Sprintf("Gap threshold in seconds (%g–%gs)", minConsolidationThreshold, maxConsolidationThreshold))
+		thresholdInput.Call("addEventListener", "change", js.FuncOf(app.updateConsolidationThreshold))
+	}
+
+	applyBtn := document.Call("getElementById", "apply-consolidation")
+	if !applyBtn.IsNull() {
+		applyBtn.Call("addEventListener", "click", js.FuncOf(app.applyConsolidation))
+	}
+
+	resetBtn := document.Call("getElementById", "reset-consolidation")
+	if !resetBtn.IsNull() {
+		resetBtn.Call("addEventListener", "click", js.FuncOf(app.resetConsolidation))
+	}
+
+	maxDurationInput := document.Call("getElementById", "consolidation-max-duration")
+	if !maxDurationInput.IsNull() {
+		maxDurationInput.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			maxDuration, err := strconv.ParseFloat(maxDurationInput.Get("value").String(), 64)
+			if err != nil || maxDuration < 0 {
+				maxDuration = 0
+				maxDurationInput.Set("value", 0)
+			}
+			app.consolidationMaxDuration = maxDuration
+			app.renderThresholdPreview()
+			return nil
+		}))
+	}
+
+	minWordsInput := document.Call("getElementById", "consolidation-min-words")
+	if !minWordsInput.IsNull() {
+		minWordsInput.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			minWords, err := strconv.Atoi(minWordsInput.Get("value").String())
+			if err != nil || minWords < 0 {
+				minWords = 0
+				minWordsInput.Set("value", 0)
+			}
+			app.consolidationMinWords = minWords
+			app.renderThresholdPreview()
+			return nil
+		}))
+	}
+
+	thresholdPreview := document.Call("getElementById", "threshold-preview")
+	if !thresholdPreview.IsNull() {
+		thresholdPreview.Call("addEventListener", "click", js.FuncOf(app.handleThresholdPreviewClick))
+	}
+
+	modeSelect := document.Call("getElementById", "consolidation-mode")
+	if !modeSelect.IsNull() {
+		modeSelect.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.consolidateBySpeaker = modeSelect.Get("value").String() == "speaker"
+			app.syncThresholdControls()
+			return nil
+		}))
+	}
+}
+
+func (app *AudioPipeApp) setupRecentFiles() {
+	document := js.Global().Get("document")
+
+	recentList := document.Call("getElementById", "recent-files-list")
+	if !recentList.IsNull() {
+		recentList.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			item := args[0].Get("target").Call("closest", "[data-recent-name]")
+			if !item.IsNull() {
+				app.openRecentFile(js.Value{}, []js.Value{item.Call("getAttribute", "data-recent-name")})
+			}
+			return nil
+		}))
+	}
+
+	urlInput := document.Call("getElementById", "transcription-url")
+	loadURLBtn := document.Call("getElementById", "load-url")
+	if !urlInput.IsNull() && !loadURLBtn.IsNull() {
+		loadURLBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return app.loadTranscriptionFromURL(js.Value{}, []js.Value{urlInput.Get("value")})
+		}))
+		urlInput.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) > 0 && args[0].Get("key").String() == "Enter" {
+				app.loadTranscriptionFromURL(js.Value{}, []js.Value{urlInput.Get("value")})
+			}
+			return nil
+		}))
+	}
+
+	clearRecentBtn := document.Call("getElementById", "clear-recent-files")
+	if !clearRecentBtn.IsNull() {
+		clearRecentBtn.Call("addEventListener", "click", js.FuncOf(app.clearRecentFiles))
+	}
+}
+
+func (app *AudioPipeApp) preventDefaults(this js.Value, args []js.Value) interface{} {
+	if len(args) > 0 {
+		args[0].Call("preventDefault")
+		args[0].Call("stopPropagation")
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) handleFileInput(this js.Value, args []js.Value) interface{} {
+	if len(args) > 0 {
+		files := args[0].Get("target").Get("files")
+		for i := 0; i < files.Length(); i++ {
+			app.processFile(files.Index(i))
+		}
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) handleFileDrop(this js.Value, args []js.Value) interface{} {
+	log.Printf("File drop event triggered")
+
+	if len(args) == 0 {
+		log.Printf("No arguments in drop event")
+		return nil
+	}
+
+	event := args[0]
+	dataTransfer := event.Get("dataTransfer")
+	if dataTransfer.IsUndefined() {
+		log.Printf("No dataTransfer in drop event")
+		return nil
+	}
+
+	files := dataTransfer.Get("files")
+	if files.IsUndefined() {
+		log.Printf("No files in dataTransfer")
+		return nil
+	}
+
+	fileCount := files.Length()
+	log.Printf("Dropped %d files", fileCount)
+
+	if fileCount == 0 {
+		log.Printf("No files to process")
+	}
+
+	var audioFiles, transcriptFiles []js.Value
+	for i := 0; i < fileCount; i++ {
+		file := files.Index(i)
+		if app.isAudioFile(file) {
+			audioFiles = append(audioFiles, file)
+		} else {
+			transcriptFiles = append(transcriptFiles, file)
+		}
+	}
+
+	if len(audioFiles) > 1 {
+		app.showToast(fmt.Sprintf("Only one audio file can be loaded at a time, using %s", audioFiles[0].Get("name").String()), "warning")
+		audioFiles = audioFiles[:1]
+	}
+
+	app.pendingDropAudio = len(audioFiles) > 0 && len(transcriptFiles) > 0
+	app.pendingDropTranscripts = 0
+	if app.pendingDropAudio {
+		app.pendingDropTranscripts = len(transcriptFiles)
+		log.Printf("Dropped audio with %d transcription file(s), waiting for all to load", len(transcriptFiles))
+	}
+
+	for _, file := range append(transcriptFiles, audioFiles...) {
+		log.Printf("Processing dropped file: %s (type: %s)", file.Get("name").String(), file.Get("type").String())
+		app.processFile(file)
+	}
+
+	return nil
+}
+
+func (app *AudioPipeApp) isAudioFile(file js.Value) bool {
+	fileName := file.Get("name").String()
+	fileType := file.Get("type").String()
+	if strings.HasPrefix(fileType, "audio/") {
+		return true
+	}
+	return !strings.HasSuffix(strings.ToLower(fileName), ".json") && app.isValidAudioFormat(fileName, fileType)
+}
+
+func (app *AudioPipeApp) droppedFileLoaded(isAudio bool) bool {
+	if !app.pendingDropAudio && app.pendingDropTranscripts == 0 {
+		return false
+	}
+
+	if isAudio {
+		app.pendingDropAudio = false
+	} else if app.pendingDropTranscripts > 0 {
+		app.pendingDropTranscripts--
+	}
+
+	if !app.pendingDropAudio && app.pendingDropTranscripts == 0 {
+		app.showVisualizationView(js.Value{}, []js.Value{})
+	}
+	return true
+}
+
+func (app *AudioPipeApp) cancelDropPairing() {
+	app.pendingDropAudio = false
+	app.pendingDropTranscripts = 0
+}
+
+func (app *AudioPipeApp) loadTranscriptionFile(this js.Value, args []js.Value) interface{} {
+	if len(args) > 0 {
+		app.processFile(args[0])
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) processFile(file js.Value) {
+	fileName := file.Get("name").String()
+
+	if app.isAudioFile(file) {
+		app.processAudioFile(file)
+		return
+	}
+
+	sizeMB := bytesToMB(file.Get("size").Float())
+	if sizeMB > maxTranscriptMB {
+		log.Printf("❌ TRANSCRIPTION TOO LARGE: %s is %.1f MB", fileName, sizeMB)
+		app.showToast(fmt.Sprintf("Transcription too large (max %.0f MB). Current: %.1f MB", maxTranscriptMB, sizeMB), "error")
+		app.showErrorState(fmt.Sprintf("%s is %.1f MB, which is more than the browser viewer can open (%.0f MB)", fileName, sizeMB, maxTranscriptMB))
+		return
+	}
+
+	if sizeMB > largeTranscriptMB {
+		app.showConfirmToast(fmt.Sprintf("%s is %.0f MB and may take a while to open. Load it anyway?", fileName, sizeMB), "LOAD", func() {
+			app.readTranscriptionFile(file)
+		}, func() {
+			log.Printf("Skipped large transcription %s", fileName)
+			app.droppedFileLoaded(false)
+		})
+		return
+	}
+
+	app.readTranscriptionFile(file)
+}
+
+func (app *AudioPipeApp) readTranscriptionFile(file js.Value) {
+	fileName := file.Get("name").String()
+	fileSize := file.Get("size").Int()
+
+	app.showLoadingState(fmt.Sprintf("Processing %s (%.1f MB)...", fileName, bytesToMB(float64(fileSize))))
+	app.updateLoadingProgress(0)
+
+	reader := js.Global().Get("FileReader").New()
+	finished := false
+	timeoutSeconds := app.calculateTimeout(fileSize)
+
+	finish := func() bool {
+		if finished {
+			return false
+		}
+		finished = true
+		app.hideLoadingProgress()
+		return true
+	}
+
+	timer := js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !finish() {
+			return nil
+		}
+		reader.Call("abort")
+		log.Printf("❌ TRANSCRIPTION READ TIMED OUT: %s after %ds", fileName, timeoutSeconds)
+		app.showToast("Reading the transcription took too long", "error")
+		app.showErrorState(fmt.Sprintf("%s: reading did not finish within %d seconds", fileName, timeoutSeconds))
+		return nil
+	}), timeoutSeconds*1000)
+
+	reader.Set("onprogress", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		if finished || !event.Get("lengthComputable").Bool() {
+			return nil
+		}
+		percent := event.Get("loaded").Float() / event.Get("total").Float() * 100
+		app.updateLoadingProgress(percent)
+		app.updateLoadingMessage(fmt.Sprintf("Reading %s: %.0f%%", fileName, percent))
+		return nil
+	}))
+
+	reader.Set("onload", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !finish() {
+			return nil
+		}
+		js.Global().Call("clearTimeout", timer)
+
+		app.parseTranscriptionData(args[0].Get("target").Get("result").String(), fileName)
+		return nil
+	}))
+
+	reader.Set("onerror", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !finish() {
+			return nil
+		}
+		js.Global().Call("clearTimeout", timer)
+		app.showToast("Failed to read file", "error")
+		app.showUploadState()
+		return nil
+	}))
+
+	reader.Call("readAsText", file)
+}
+
+func (app *AudioPipeApp) loadTranscriptionFromURL(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || strings.TrimSpace(args[0].String()) == "" {
+		app.showToast("Enter a transcription URL", "warning")
+		return nil
+	}
+
+	rawURL := strings.TrimSpace(args[0].String())
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		app.showToast(fmt.Sprintf("Invalid URL: %s", rawURL), "error")
+		return nil
+	}
+
+	fileName := path.Base(parsed.Path)
+	if fileName == "." || fileName == "/" {
+		fileName = parsed.Host
+	}
+
+	app.showLoadingState("Fetching " + rawURL + "...")
+
+	js.Global().Call("fetch", rawURL).Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		response := args[0]
+		if !response.Get("ok").Bool() {
+			app.showToast(fmt.Sprintf("Failed to fetch transcription: HTTP %d", response.Get("status").Int()), "error")
+			app.showUploadState()
+			return nil
+		}
+
+		return response.Call("text").Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			app.parseTranscriptionData(args[0].String(), fileName)
+			return nil
+		}))
+	})).Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		log.Printf("Error fetching %s: %v", rawURL, args[0])
+		app.showToast("Network error while fetching transcription", "error")
+		app.showUploadState()
+		return nil
+	}))
+
+	return nil
+}
+
+func (app *AudioPipeApp) parseTranscriptionData(jsonData, fileName string) {
+	decoder := newTranscriptionDecoder(strings.NewReader(jsonData))
+	budget := time.Duration(app.calculateTimeout(len(jsonData))) * time.Second
+	started := time.Now()
+
+	app.updateLoadingMessage(fmt.Sprintf("Parsing %s...", fileName))
+
+	var step js.Func
+	step = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		done, err := decoder.decode(parseSegmentsPerChunk)
+		if err != nil {
+			step.Release()
+			app.hideLoadingProgress()
+			app.showToast("Invalid JSON format", "error")
+			app.showErrorState(fmt.Sprintf("%s: %v", fileName, err))
+			return nil
+		}
+
+		if done {
+			step.Release()
+			app.hideLoadingProgress()
+			log.Printf("📄 PARSED %s in %s", fileName, time.Since(started).Round(time.Millisecond))
+			app.loadTranscriptionData(decoder.data, decoder.bookmarks, jsonData, fileName)
+			return nil
+		}
+
+		if time.Since(started) > budget {
+			step.Release()
+			app.hideLoadingProgress()
+			log.Printf("❌ TRANSCRIPTION PARSE TIMED OUT: %s after %s, %d segments read", fileName, budget, len(decoder.data.Segments))
+			app.showToast("Parsing the transcription took too long", "error")
+			app.showErrorState(fmt.Sprintf("%s: parsing did not finish within %s (%d segments read)", fileName, budget, len(decoder.data.Segments)))
+			return nil
+		}
+
+		percent := float64(decoder.offset()) / float64(len(jsonData)) * 100
+		app.updateLoadingProgress(percent)
+		app.updateLoadingMessage(fmt.Sprintf("Parsing %s: %d segments (%.0f%%)", fileName, len(decoder.data.Segments), percent))
+		js.Global().Call("setTimeout", step, 0)
+		return nil
+	})
+
+	js.Global().Call("setTimeout", step, 0)
+}
+
+func (app *AudioPipeApp) loadTranscriptionData(transcriptionData TranscriptionData, bookmarks json.RawMessage, jsonData, fileName string) {
+	transcriptionData.Segments = app.validateSegments(transcriptionData.Segments)
+
+	if len(transcriptionData.Segments) == 0 {
+		app.showToast("No segments found in transcription", "warning")
+		app.showUploadState()
+		return
+	}
+
+	if app.normalizeImportedText {
+		if changed := normalizeSegments(transcriptionData.Segments); changed > 0 {
+			log.Printf("Normalized quotes and whitespace in %d segment(s) of %s", changed, fileName)
+		}
+	}
+	orderSegments(transcriptionData.Segments)
+
+	app.warnOversizedSegments(transcriptionData.Segments)
+	app.addRecentFile(fileName, jsonData)
+
+	index := app.addTranscription(fileName, &transcriptionData)
+	app.activateTranscription(index)
+
+	var exported []Bookmark
+	if len(bookmarks) > 0 && json.Unmarshal(bookmarks, &exported) == nil && len(exported) > 0 {
+		app.bookmarks = exported
+		sort.SliceStable(app.bookmarks, func(i, j int) bool {
+			return app.bookmarks[i].Time < app.bookmarks[j].Time
+		})
+		app.saveSession()
+		app.renderBookmarks()
+	}
+	app.showToast(fmt.Sprintf("Loaded %s with %d segments", fileName, len(transcriptionData.Segments)), "success")
+
+	if app.droppedFileLoaded(false) {
+		return
+	}
+
+	if app.audioData != nil && fileStem(app.audioData.FileName) == fileStem(fileName) {
+		app.showToast(fmt.Sprintf("Paired %s with audio %s", fileName, app.audioData.FileName), "info")
+		app.showVisualizationView(js.Value{}, []js.Value{})
+		return
+	}
+	app.showPreferredView()
+}
+
+func (app *AudioPipeApp) pairAudioWithTranscription(audioName string) bool {
+	stem := fileStem(audioName)
+	for i, loaded := range app.transcriptions {
+		if fileStem(loaded.FileName) != stem {
+			continue
+		}
+
+		app.showToast(fmt.Sprintf("Paired audio %s with %s", audioName, loaded.FileName), "info")
+		if i == app.activeTranscription {
+			return false
+		}
+		app.activateTranscription(i)
+		return true
+	}
+
+	if app.transcriptionData != nil {
+		log.Printf("🔗 NO PAIRING: %s does not match any loaded transcription name", audioName)
+	}
+	return false
+}
+
+func (app *AudioPipeApp) addTranscription(fileName string, data *TranscriptionData) int {
+	for i, loaded := range app.transcriptions {
+		if loaded.FileName == fileName {
+			app.transcriptions[i].Data = data
+			return i
+		}
+	}
+
+	app.transcriptions = append(app.transcriptions, LoadedTranscription{FileName: fileName, Data: data})
+	return len(app.transcriptions) - 1
+}
+
+func (app *AudioPipeApp) activateTranscription(index int) {
+	loaded := app.transcriptions[index]
+	app.activeTranscription = index
+	app.transcriptionData = loaded.Data
+	app.transcriptionFileName = loaded.FileName
+	app.resetSegmentState()
+
+	app.calculateStatistics()
+	app.generateSpeakerColors()
+	app.consolidatedData = nil
+	if app.isConsolidated {
+		app.consolidatedData = app.consolidateSegmentsByThreshold(app.activeConsolidationThreshold(), app.consolidationMaxDuration, app.consolidationMinWords)
+	}
+
+	app.updateStatistics()
+	app.renderThresholdPreview()
+	app.renderTranscriptionSelector()
+	app.saveSession()
+	app.checkDurationMismatch()
+	app.checkAudioMatchesTranscript()
+}
+
+func (app *AudioPipeApp) selectTranscription(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	index := args[0].Int()
+	if index < 0 || index >= len(app.transcriptions) || index == app.activeTranscription {
+		return nil
+	}
+
+	app.activateTranscription(index)
+	app.refreshCurrentView()
+	app.showToast(fmt.Sprintf("Switched to %s", app.transcriptionFileName), "info")
+	return nil
+}
+
+func (app *AudioPipeApp) renderTranscriptionSelector() {
+	selector := js.Global().Get("document").Call("getElementById", "transcription-select")
+	if selector.IsNull() {
+		return
+	}
+
+	if len(app.transcriptions) < 2 {
+		selector.Get("style").Set("display", "none")
+		return
+	}
+
+	var htmlBuilder strings.Builder
+	for i, loaded := range app.transcriptions {
+		selected := ""
+		if i == app.activeTranscription {
+			selected = " selected"
+		}
+		htmlBuilder.WriteString(fmt.Sprintf(`<option value="%d"%s>%s (%d)</option>`,
+			i, selected, htmlEscape(loaded.FileName), len(loaded.Data.Segments)))
+	}
+
+	selector.Set("innerHTML", htmlBuilder.String())
+	selector.Get("style").Set("display", "")
+}
+
+func (app *AudioPipeApp) setupTranscriptionSelector() {
+	selector := js.Global().Get("document").Call("getElementById", "transcription-select")
+	if selector.IsNull() {
+		return
+	}
+
+	selector.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		index, err := strconv.Atoi(selector.Get("value").String())
+		if err != nil {
+			return nil
+		}
+		return app.selectTranscription(js.Value{}, []js.Value{js.ValueOf(index)})
+	}))
+}
+
+func (app *AudioPipeApp) resetSegmentState() {
+	app.loopRange = nil
+	app.undoStack = nil
+	app.updateUndoButton()
+	app.selectedSegments = make(map[int]bool)
+	app.updateSelectionCount()
+	app.speakerFilter = ""
+	app.updateSpeakerFilterStatus()
+	app.soloedSpeakers = make(map[string]bool)
+	app.mutedSpeakers = make(map[string]bool)
+	app.bookmarks = nil
+	app.renderBookmarks()
+}
+
+func (app *AudioPipeApp) saveSession() {
+	if app.transcriptionData == nil {
+		return
+	}
+
+	data, err := json.Marshal(SessionState{
+		FileName:                 app.transcriptionFileName,
+		Transcription:            app.transcriptionData,
+		RawText:                  collectRawText(app.transcriptionData.Segments),
+		SpeakerColors:            app.speakerColors,
+		ConsolidationThreshold:   app.consolidationThreshold,
+		ConsolidateBySpeaker:     app.consolidateBySpeaker,
+		ConsolidationMaxDuration: app.consolidationMaxDuration,
+		ConsolidationMinWords:    app.consolidationMinWords,
+		Bookmarks:                app.bookmarks,
+		IsConsolidated:           app.isConsolidated,
+		SavedAt:                  time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.Printf("Error encoding session: %v", err)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Could not save session: %v", r)
+		}
+	}()
+
+	js.Global().Get("localStorage").Call("setItem", sessionKey, string(data))
+}
+
+func (app *AudioPipeApp) loadSession() *SessionState {
+	saved := js.Global().Get("localStorage").Call("getItem", sessionKey)
+	if saved.IsNull() {
+		return nil
+	}
+
+	var state SessionState
+	if err := json.Unmarshal([]byte(saved.String()), &state); err != nil {
+		log.Printf("Discarding unreadable session: %v", err)
+		return nil
+	}
+
+	if state.Transcription == nil || len(state.Transcription.Segments) == 0 {
+		return nil
+	}
+	return &state
+}
+
+func (app *AudioPipeApp) offerSessionRestore() {
+	state := app.loadSession()
+	if state == nil {
+		return
+	}
+
+	name := state.FileName
+	if name == "" {
+		name = "previous transcription"
+	}
+
+	app.showConfirmToast(fmt.Sprintf("Restore %s (%d segments) from your last session?", name, len(state.Transcription.Segments)),
+		"RESTORE", func() {
+			app.restoreSession(state)
+		}, nil)
+}
+
+func (app *AudioPipeApp) restoreSession(state *SessionState) {
+	applyRawText(state.Transcription.Segments, state.RawText)
+	orderSegments(state.Transcription.Segments)
+	app.transcriptions = []LoadedTranscription{{FileName: state.FileName, Data: state.Transcription}}
+	app.activeTranscription = 0
+	app.renderTranscriptionSelector()
+	app.transcriptionData = state.Transcription
+	app.transcriptionFileName = state.FileName
+	app.resetSegmentState()
+
+	app.calculateStatistics()
+	app.speakerColors = make(map[string]string)
+	app.generateSpeakerColors()
+	for speaker, color := range state.SpeakerColors {
+		app.speakerColors[speaker] = color
+	}
+
+	app.consolidationThreshold = state.ConsolidationThreshold
+	app.consolidateBySpeaker = state.ConsolidateBySpeaker
+	app.consolidationMaxDuration = math.Max(state.ConsolidationMaxDuration, 0)
+	app.consolidationMinWords = max(state.ConsolidationMinWords, 0)
+	app.syncThresholdControls()
+	app.bookmarks = state.Bookmarks
+	app.isConsolidated = state.IsConsolidated
+	app.consolidatedData = nil
+	if app.isConsolidated {
+		app.consolidatedData = app.consolidateSegmentsByThreshold(app.activeConsolidationThreshold(), app.consolidationMaxDuration, app.consolidationMinWords)
+	}
+
+	app.updateStatistics()
+	app.renderThresholdPreview()
+	app.showToast(fmt.Sprintf("Restored %d segments, load the audio file again for playback", len(app.transcriptionData.Segments)), "success")
+
+	app.showPreferredView()
+}
+
+func (app *AudioPipeApp) clearSession(this js.Value, args []js.Value) interface{} {
+	js.Global().Get("localStorage").Call("removeItem", sessionKey)
+	app.showToast("Saved session cleared", "info")
+	return nil
+}
+
+func (app *AudioPipeApp) validateSegments(segments []Segment) []Segment {
+	valid, problems := sanitizeSegments(segments)
+	if len(problems) == 0 {
+		return valid
+	}
+
+	for _, problem := range problems {
+		log.Printf("Segment schema problem: %s", problem)
+	}
+
+	skipped := len(segments) - len(valid)
+	app.showToast(fmt.Sprintf("%d segment problem(s) found, %d segment(s) skipped, see the console for details", len(problems), skipped), "warning")
+	return valid
+}
+
+func (app *AudioPipeApp) warnOversizedSegments(segments []Segment) {
+	oversized := 0
+	for i, segment := range segments {
+		if length := len([]rune(segment.Text)); length > suspiciousSegmentLength {
+			log.Printf("Segment %d (%s, %.2fs) has %d characters, possible bad data", i, segment.Speaker, segment.Start, length)
+			oversized++
+		}
+	}
+
+	if oversized > 0 {
+		app.showToast(fmt.Sprintf("%d segment(s) exceed %d characters, check the source data", oversized, suspiciousSegmentLength), "warning")
+	}
+}
+
+func (app *AudioPipeApp) loadRecentFiles() []RecentFile {
+	saved := js.Global().Get("localStorage").Call("getItem", recentFilesKey)
+	if saved.IsNull() {
+		return []RecentFile{}
+	}
+
+	var recent []RecentFile
+	if err := json.Unmarshal([]byte(saved.String()), &recent); err != nil {
+		log.Printf("Discarding unreadable recent files list: %v", err)
+		return []RecentFile{}
+	}
+	return recent
+}
+
+func (app *AudioPipeApp) saveRecentFiles(recent []RecentFile) {
+	data, err := json.Marshal(recent)
+	if err != nil {
+		log.Printf("Failed to encode recent files: %v", err)
+		return
+	}
+	js.Global().Get("localStorage").Call("setItem", recentFilesKey, string(data))
+}
+
+func (app *AudioPipeApp) cacheRecentTranscript(fileName, jsonData string) (cached bool) {
+	if len(jsonData) > maxCachedTranscriptBytes {
+		return false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Could not cache %s for recent files: %v", fileName, r)
+			cached = false
+		}
+	}()
+
+	js.Global().Get("localStorage").Call("setItem", recentFileCachePrefix+fileName, jsonData)
+	return true
+}
+
+func (app *AudioPipeApp) addRecentFile(fileName, jsonData string) {
+	localStorage := js.Global().Get("localStorage")
+
+	recent := []RecentFile{{
+		Name:     fileName,
+		LoadedAt: time.Now().Format(time.RFC3339),
+		Cached:   app.cacheRecentTranscript(fileName, jsonData),
+	}}
+
+	for _, entry := range app.loadRecentFiles() {
+		if entry.Name == fileName {
+			continue
+		}
+		if len(recent) >= maxRecentFiles {
+			localStorage.Call("removeItem", recentFileCachePrefix+entry.Name)
+			continue
+		}
+		recent = append(recent, entry)
+	}
+
+	if !recent[0].Cached {
+		localStorage.Call("removeItem", recentFileCachePrefix+fileName)
+	}
+
+	app.saveRecentFiles(recent)
+}
+
+func (app *AudioPipeApp) openRecentFile(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fileName := args[0].String()
+	cached := js.Global().Get("localStorage").Call("getItem", recentFileCachePrefix+fileName)
+	if !cached.IsNull() {
+		app.showLoadingState("Reopening " + fileName + "...")
+		app.parseTranscriptionData(cached.String(), fileName)
+		return nil
+	}
+
+	app.showToast(fmt.Sprintf("%s is not cached, please select it again", fileName), "info")
+	fileInput := js.Global().Get("document").Call("getElementById", "file-input")
+	if !fileInput.IsNull() {
+		fileInput.Call("click")
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) clearRecentFiles(this js.Value, args []js.Value) interface{} {
+	localStorage := js.Global().Get("localStorage")
+	for _, entry := range app.loadRecentFiles() {
+		localStorage.Call("removeItem", recentFileCachePrefix+entry.Name)
+	}
+	localStorage.Call("removeItem", recentFilesKey)
+
+	app.renderRecentFiles()
+	app.showToast("Recent files cleared", "info")
+	return nil
+}
+
+func (app *AudioPipeApp) renderRecentFiles() {
+	document := js.Global().Get("document")
+	panel := document.Call("getElementById", "recent-files")
+	list := document.Call("getElementById", "recent-files-list")
+	if panel.IsNull() || list.IsNull() {
+		return
+	}
+
+	recent := app.loadRecentFiles()
+	if len(recent) == 0 {
+		panel.Get("style").Set("display", "none")
+		list.Set("innerHTML", "")
+		return
+	}
+
+	var htmlBuilder strings.Builder
+	for _, entry := range recent {
+		icon := "fa-file-import"
+		if entry.Cached {
+			icon = "fa-file-code"
+		}
+
+		loadedAt := entry.LoadedAt
+		if parsed, err := time.Parse(time.RFC3339, entry.LoadedAt); err == nil {
+			loadedAt = parsed.Local().Format("Jan 2 15:04")
+		}
+
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<li class="recent-file-item" data-recent-name="%s">
+				<i class="fas %s"></i>
+				<span class="recent-file-name">%s</span>
+				<span class="recent-file-date">%s</span>
+			</li>
+		`, htmlEscape(entry.Name), icon, htmlEscape(entry.Name), loadedAt))
+	}
+
+	list.Set("innerHTML", htmlBuilder.String())
+	panel.Get("style").Set("display", "block")
+}
+
+func (app *AudioPipeApp) calculateStatistics() {
+	if app.transcriptionData == nil {
+		return
+	}
+
+	app.statistics = computeStatistics(app.transcriptionData.Segments, app.silenceThreshold)
+	app.silenceGaps = app.detectSilences(app.silenceThreshold)
+
+	duration := app.transcriptionData.Duration
+	if metadataDuration, ok := app.transcriptionData.Metadata["duration"].(float64); ok && metadataDuration > duration {
+		duration = metadataDuration
+	}
+	if duration > app.statistics.TotalDuration {
+		app.statistics.TotalDuration = duration
+	}
+}
+
+func (app *AudioPipeApp) detectOverlaps() []Overlap {
+	return findOverlaps(app.sortedSegments())
+}
+
+func (app *AudioPipeApp) seekToNextOverlap(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil || app.audioData == nil {
+		return nil
+	}
+
+	overlaps := app.detectOverlaps()
+	if len(overlaps) == 0 {
+		app.showToast("No overlapping speech found", "info")
+		return nil
+	}
+
+	target := overlaps[0]
+	for _, overlap := range overlaps {
+		if overlap.Start > app.currentTime+0.05 {
+			target = overlap
+			break
+		}
+	}
+
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(target.Start)})
+	app.showToast(fmt.Sprintf("Overlap %s / %s at %s", target.SpeakerA, target.SpeakerB, formatTime(target.Start)), "info")
+	return nil
+}
+
+func (app *AudioPipeApp) detectSilences(threshold float64) []Gap {
+	return findSilences(app.sortedSegments(), threshold)
+}
+
+func (app *AudioPipeApp) calculateTurnMetrics() TurnMetrics {
+	return computeTurnMetrics(app.sortedSegments())
+}
+
+func (app *AudioPipeApp) calculateSpeakerStatistics() []SpeakerStat {
+	if app.transcriptionData == nil {
+		return []SpeakerStat{}
+	}
+
+	speakers := app.getUniqueSpeakers()
+	stats := make([]SpeakerStat, 0, len(speakers))
+	totalSpeaking := 0.0
+
+	for _, speaker := range speakers {
+		segments := app.getSegmentsForSpeaker(speaker)
+		words := countWords(segments)
+
+		duration := app.getTotalDurationForSpeaker(segments)
+		totalSpeaking += duration
+
+		stats = append(stats, SpeakerStat{
+			Speaker:      speaker,
+			Duration:     duration,
+			SegmentCount: len(segments),
+			WordCount:    words,
+		})
+	}
+
+	if totalSpeaking > 0 {
+		for i := range stats {
+			stats[i].Percentage = (stats[i].Duration / totalSpeaking) * 100
+		}
+	}
+
+	turns := app.calculateTurnMetrics()
+	for i := range stats {
+		stats[i].TurnCount = turns.SpeakerTurns[stats[i].Speaker]
+		if stats[i].TurnCount > 0 {
+			stats[i].AverageTurn = turns.SpeakerTurnTime[stats[i].Speaker] / float64(stats[i].TurnCount)
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Duration > stats[j].Duration
+	})
+
+	return stats
+}
+
+func (app *AudioPipeApp) generateSpeakerColors() {
+	if app.transcriptionData == nil {
+		return
+	}
+
+	colors := app.speakerPaletteForTheme()
+
+	speakers := app.getUniqueSpeakers()
+	overrides := app.loadSpeakerColorOverrides()
+	for i, speaker := range speakers {
+		if color, ok := overrides[speaker]; ok {
+			app.speakerColors[speaker] = color
+			continue
+		}
+		app.speakerColors[speaker] = colors[i%len(colors)]
+	}
+}
+
+func (app *AudioPipeApp) speakerPaletteForTheme() []string {
+	if app.isDarkTheme {
+		return darkSpeakerPalette
+	}
+	return speakerPalette
+}
+
+func (app *AudioPipeApp) loadSpeakerColorOverrides() map[string]string {
+	overrides := make(map[string]string)
+	saved := js.Global().Get("localStorage").Call("getItem", speakerColorsKey)
+	if saved.IsNull() {
+		return overrides
+	}
+
+	if err := json.Unmarshal([]byte(saved.String()), &overrides); err != nil {
+		log.Printf("Discarding unreadable speaker colors: %v", err)
+		return make(map[string]string)
+	}
+	return overrides
+}
+
+func (app *AudioPipeApp) setSpeakerColor(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return nil
+	}
+
+	speaker := args[0].String()
+	color := strings.TrimSpace(args[1].String())
+	if !strings.HasPrefix(color, "#") {
+		color = "#" + color
+	}
+
+	if !hexColorPattern.MatchString(color) {
+		app.showToast(fmt.Sprintf("Invalid color %q, use #RRGGBB", args[1].String()), "error")
+		return nil
+	}
+	color = strings.ToLower(color)
+
+	app.speakerColors[speaker] = color
+
+	overrides := app.loadSpeakerColorOverrides()
+	overrides[speaker] = color
+	if data, err := json.Marshal(overrides); err == nil {
+		js.Global().Get("localStorage").Call("setItem", speakerColorsKey, string(data))
+	}
+
+	app.saveSession()
+	app.refreshCurrentView()
+	app.showToast(fmt.Sprintf("%s color set to %s", speaker, color), "success")
+	return nil
+}
+
+func (app *AudioPipeApp) getUniqueSpeakers() []string {
+	if app.transcriptionData == nil {
+		return []string{}
+	}
+	return uniqueSpeakers(app.transcriptionData.Segments)
+}
+
+func (app *AudioPipeApp) hideAllStates() {
+	document := js.Global().Get("document")
+	sections := []string{
+		"welcome-state", "loading-state", "error-state", "no-results-state",
+		"transcription-content", "visualization-content", "speaker-legend",
 	}
 
 	for _, section := range sections {
@@ -491,501 +2414,2872 @@ func (app *AudioPipeApp) hideAllStates() {
 	}
 }
 
-func (app *AudioPipeApp) showUploadState() {
-	app.hideAllStates()
+func (app *AudioPipeApp) showUploadState() {
+	app.cancelDropPairing()
+	app.hideAllStates()
+	document := js.Global().Get("document")
+	welcomeState := document.Call("getElementById", "welcome-state")
+	if !welcomeState.IsNull() {
+		welcomeState.Get("style").Set("display", "block")
+	}
+	app.renderRecentFiles()
+}
+
+func (app *AudioPipeApp) showErrorState(message string) {
+	app.cancelDropPairing()
+	app.hideAllStates()
+	document := js.Global().Get("document")
+	errorState := document.Call("getElementById", "error-state")
+	if errorState.IsNull() {
+		app.showUploadState()
+		return
+	}
+
+	errorMessage := document.Call("getElementById", "error-message")
+	if !errorMessage.IsNull() {
+		errorMessage.Set("textContent", message)
+	}
+	errorState.Get("style").Set("display", "block")
+}
+
+func (app *AudioPipeApp) showLoadingState(message string) {
+	app.hideAllStates()
+	document := js.Global().Get("document")
+	loadingState := document.Call("getElementById", "loading-state")
+	if !loadingState.IsNull() {
+		loadingState.Get("style").Set("display", "block")
+
+		loadingMessage := document.Call("getElementById", "loading-message")
+		if !loadingMessage.IsNull() {
+			loadingMessage.Set("textContent", message)
+		}
+	}
+}
+
+func (app *AudioPipeApp) showPreferredView() {
+	if js.Global().Get("localStorage").Call("getItem", viewKey).String() == "visualization" {
+		app.showVisualizationView(js.Value{}, []js.Value{})
+		return
+	}
+	app.showTimelineView(js.Value{}, []js.Value{})
+}
+
+func (app *AudioPipeApp) showTimelineView(this js.Value, args []js.Value) interface{} {
+	app.currentView = "timeline"
+	js.Global().Get("localStorage").Call("setItem", viewKey, app.currentView)
+	app.hideAllStates()
+
+	if app.transcriptionData != nil {
+		app.showTimelineContent()
+		app.setActiveView("view-timeline")
+	} else {
+		app.showUploadState()
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) showVisualizationView(this js.Value, args []js.Value) interface{} {
+	app.currentView = "visualization"
+	js.Global().Get("localStorage").Call("setItem", viewKey, app.currentView)
+	app.hideAllStates()
+
+	if app.transcriptionData != nil || app.audioData != nil {
+		app.showVisualizationContent()
+		app.setActiveView("view-visualization")
+	} else {
+		app.showUploadState()
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) showTimelineContent() {
+	document := js.Global().Get("document")
+	transcriptionContent := document.Call("getElementById", "transcription-content")
+	if !transcriptionContent.IsNull() {
+		transcriptionContent.Get("style").Set("display", "block")
+		app.renderTimeline()
+	}
+
+	speakerLegend := document.Call("getElementById", "speaker-legend")
+	if !speakerLegend.IsNull() {
+		speakerLegend.Get("style").Set("display", "flex")
+	}
+}
+
+func (app *AudioPipeApp) showVisualizationContent() {
+	document := js.Global().Get("document")
+	visualizationContent := document.Call("getElementById", "visualization-content")
+	if !visualizationContent.IsNull() {
+		visualizationContent.Get("style").Set("display", "block")
+		app.renderSpeakingOrderStrip()
+		app.renderActivityStrip()
+		app.renderSpeakerTimelines()
+		app.renderSpeakerStatistics()
+		app.renderSpeakingTimeChart()
+	}
+}
+
+func (app *AudioPipeApp) setActiveView(activeButtonId string) {
+	document := js.Global().Get("document")
+	viewButtons := []string{"view-timeline", "view-speakers", "view-visualization"}
+
+	for _, buttonId := range viewButtons {
+		button := document.Call("getElementById", buttonId)
+		if !button.IsNull() {
+			if buttonId == activeButtonId {
+				button.Get("classList").Call("add", "active")
+			} else {
+				button.Get("classList").Call("remove", "active")
+			}
+		}
+	}
+}
+
+func (app *AudioPipeApp) updateStatistics() {
+	document := js.Global().Get("document")
+
+	segmentCount := document.Call("getElementById", "segment-count")
+	if !segmentCount.IsNull() {
+		segmentCount.Set("textContent", strconv.Itoa(app.statistics.SegmentCount))
+	}
+
+	speakerCount := document.Call("getElementById", "speaker-count")
+	if !speakerCount.IsNull() {
+		speakerCount.Set("textContent", strconv.Itoa(app.statistics.SpeakerCount))
+	}
+
+	totalDuration := document.Call("getElementById", "total-duration")
+	if !totalDuration.IsNull() {
+		totalDuration.Set("textContent", app.formatDuration(app.statistics.TotalDuration))
+	}
+
+	wordCount := document.Call("getElementById", "word-count")
+	if !wordCount.IsNull() {
+		wordCount.Set("textContent", strconv.Itoa(app.statistics.WordCount))
+	}
+
+	wpm := document.Call("getElementById", "wpm")
+	if !wpm.IsNull() {
+		wpm.Set("textContent", fmt.Sprintf("%.1f", app.statistics.WordsPerMinute))
+	}
+
+	overlapCount := document.Call("getElementById", "overlap-count")
+	if !overlapCount.IsNull() {
+		overlapCount.Set("textContent", strconv.Itoa(app.statistics.OverlapCount))
+	}
+
+	silence := document.Call("getElementById", "silence-duration")
+	if !silence.IsNull() {
+		silence.Set("textContent", app.formatDuration(app.statistics.SilenceDuration))
+	}
+
+	turnCount := document.Call("getElementById", "turn-count")
+	if !turnCount.IsNull() {
+		turnCount.Set("textContent", strconv.Itoa(app.statistics.TurnCount))
+	}
+
+	interruptionCount := document.Call("getElementById", "interruption-count")
+	if !interruptionCount.IsNull() {
+		interruptionCount.Set("textContent", strconv.Itoa(app.statistics.InterruptionCount))
+	}
+
+	app.renderTranscriptionInfo()
+}
+
+func (app *AudioPipeApp) renderTranscriptionInfo() {
+	panel := js.Global().Get("document").Call("getElementById", "transcription-info")
+	if panel.IsNull() {
+		return
+	}
+
+	if app.transcriptionData == nil {
+		panel.Get("style").Set("display", "none")
+		return
+	}
+
+	data := app.transcriptionData
+	var htmlBuilder strings.Builder
+	writeItem := func(label, value string) {
+		htmlBuilder.WriteString(fmt.Sprintf(`<span class="info-item"><span class="info-label">%s</span> %s</span>`,
+			htmlEscape(label), htmlEscape(value)))
+	}
+
+	if data.Language != "" {
+		writeItem("LANGUAGE", data.Language)
+	}
+	if data.Model != "" {
+		writeItem("MODEL", data.Model)
+	}
+	if data.Duration > 0 {
+		writeItem("AUDIO", app.formatDuration(data.Duration))
+	}
+
+	keys := make([]string, 0, len(data.Metadata))
+	for key := range data.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := data.Metadata[key].(type) {
+		case string, float64, bool:
+			writeItem(strings.ToUpper(key), fmt.Sprint(value))
+		}
+	}
+
+	if htmlBuilder.Len() == 0 {
+		panel.Get("style").Set("display", "none")
+		return
+	}
+
+	panel.Set("innerHTML", htmlBuilder.String())
+	panel.Get("style").Set("display", "flex")
+}
+
+func (app *AudioPipeApp) formatSegmentTime(seconds float64) string {
+	if app.showMilliseconds {
+		return formatTimePrecise(seconds)
+	}
+	return formatTime(seconds)
+}
+
+func (app *AudioPipeApp) formatDuration(seconds float64) string {
+	scale := math.Pow(10, float64(app.durationPrecision))
+	seconds = math.Round(seconds*scale) / scale
+
+	if seconds < 60 {
+		return strconv.FormatFloat(seconds, 'f', app.durationPrecision, 64) + "s"
+	}
+
+	wholeMins := int(seconds) / 60
+	width := 2
+	if app.durationPrecision > 0 {
+		width = 3 + app.durationPrecision
+	}
+	secs := seconds - float64(wholeMins*60)
+	if wholeMins >= 60 {
+		return fmt.Sprintf("%d:%02d:%0*.*f", wholeMins/60, wholeMins%60, width, app.durationPrecision, secs)
+	}
+	return fmt.Sprintf("%d:%0*.*f", wholeMins, width, app.durationPrecision, secs)
+}
+
+func (app *AudioPipeApp) toggleTheme(this js.Value, args []js.Value) interface{} {
+	body := js.Global().Get("document").Get("body")
+	localStorage := js.Global().Get("localStorage")
+
+	if app.isDarkTheme {
+		body.Get("classList").Call("remove", "dark-theme")
+		localStorage.Call("setItem", "theme", "light")
+		app.isDarkTheme = false
+	} else {
+		body.Get("classList").Call("add", "dark-theme")
+		localStorage.Call("setItem", "theme", "dark")
+		app.isDarkTheme = true
+	}
+
+	app.updateThemeIcon()
+
+	if app.transcriptionData != nil {
+		app.generateSpeakerColors()
+		app.refreshCurrentView()
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) renderTimeline() {
+	if app.transcriptionData == nil {
+		return
+	}
+
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "transcription-content")
+	if container.IsNull() {
+		return
+	}
+
+	app.updateSpeakerFilterStatus()
+	app.renderSpeakerLegend()
+
+	rowCount := app.timelineRowCount()
+	app.virtualized = rowCount > virtualizeThreshold
+	if app.virtualized {
+		app.visibleRows = nil
+		if query := strings.ToLower(strings.TrimSpace(app.searchQuery)); query != "" || app.rowFiltersActive() {
+			app.visibleRows = app.matchingRows(query)
+		}
+		app.renderVirtualWindow(true)
+		return
+	}
+
+	var htmlBuilder strings.Builder
+	for i := 0; i < rowCount; i++ {
+		if !app.rowPassesFilters(i) {
+			continue
+		}
+		htmlBuilder.WriteString(app.renderTimelineRow(i))
+		if i+1 < rowCount && app.rowPassesFilters(i+1) {
+			htmlBuilder.WriteString(app.renderMergeDivider(i))
+		}
+	}
+
+	container.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) timelineRowCount() int {
+	if app.isConsolidated && len(app.consolidatedData) > 0 {
+		return len(app.consolidatedData)
+	}
+	return len(app.transcriptionData.Segments)
+}
+
+func (app *AudioPipeApp) renderTimelineRow(i int) string {
+	if app.isConsolidated && len(app.consolidatedData) > 0 {
+		return app.renderConsolidatedRow(i, app.consolidatedData[i])
+	}
+	return app.renderSegmentRow(i, app.transcriptionData.Segments[i])
+}
+
+func (app *AudioPipeApp) renderConsolidatedRow(i int, segment ConsolidatedSegment) string {
+	speakerColor, rowStyle := app.rowColors(segment.Speaker, meanConfidence(segment.Segments))
+
+	return fmt.Sprintf(`
+		<div class="timeline-segment-item consolidated%s" data-start="%.2f" data-end="%.2f" data-block="%d" data-speaker="%s"%s>
+			<div class="segment-header">
+				<div class="speaker-info">
+					<div class="speaker-badge" data-action="filter-speaker" title="Show only this speaker" style="background-color: %s"></div>
+					<span class="speaker-name">%s</span>
+				</div>
+				<div class="segment-header-right">
+					<div class="segment-meta">%d words · %s</div>
+					<div class="segment-time">
+						%s - %s
+					</div>
+					<div class="segment-actions">
+						<button class="segment-action-btn" data-action="copy" title="Copy text">
+							<i class="fas fa-copy"></i>
+						</button>
+						<button class="segment-action-btn" data-action="loop" title="Loop this block">
+							<i class="fas fa-redo"></i>
+						</button>
+					</div>
+				</div>
+			</div>
+			%s
+		</div>
+		`, app.loopClass(segment.Start, segment.End), segment.Start, segment.End, i, htmlEscape(segment.Speaker), rowStyle, speakerColor, htmlEscape(segment.Speaker),
+		segment.WordCount, app.formatDuration(segment.End-segment.Start),
+		app.formatSegmentTime(segment.Start), app.formatSegmentTime(segment.End), app.renderBlockLines(segment))
+}
+
+func (app *AudioPipeApp) rowColors(speaker string, confidence *float64) (badgeColor, rowStyle string) {
+	if !app.colorByConfidence {
+		return app.speakerColors[speaker], ""
+	}
+
+	color := confidenceColor(confidence)
+	return color, fmt.Sprintf(` style="border-left: 4px solid %s"`, color)
+}
+
+func (app *AudioPipeApp) renderBlockLines(block ConsolidatedSegment) string {
+	if len(block.Segments) < 2 {
+		return app.renderSegmentText(block.Text)
+	}
+
+	var htmlBuilder strings.Builder
+	htmlBuilder.WriteString(`<div class="block-lines">`)
+	for _, line := range block.Segments {
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<div class="block-line" data-action="seek-line" data-start="%.2f" title="Seek to %s">
+				<span class="block-line-time">%s</span>
+				<span class="block-line-text">%s</span>
+			</div>
+		`, line.Start, formatTime(line.Start), app.formatSegmentTime(line.Start), htmlEscape(line.Text)))
+	}
+	htmlBuilder.WriteString(`</div>`)
+	return htmlBuilder.String()
+}
+
+func (app *AudioPipeApp) renderSegmentRow(i int, segment Segment) string {
+	speakerColor, rowStyle := app.rowColors(segment.Speaker, segment.Confidence)
+
+	checked := ""
+	if app.selectedSegments[i] {
+		checked = "checked"
+	}
+
+	confidenceClass, confidenceIcon := "", ""
+	if app.isLowConfidence(segment) {
+		confidenceClass = " low-confidence"
+		confidenceIcon = fmt.Sprintf(`<i class="fas fa-exclamation-triangle low-confidence-icon" title="Confidence %.2f"></i>`, app.segmentConfidence(segment))
+	}
+
+	return fmt.Sprintf(`
+		<div class="timeline-segment-item%s%s" data-start="%.2f" data-end="%.2f" data-index="%d" data-speaker="%s"%s>
+			<div class="segment-header">
+				<div class="speaker-info">
+					<input type="checkbox" class="segment-select" data-index="%d" title="Select for export" %s>
+					<div class="speaker-badge" data-action="filter-speaker" title="Show only this speaker" style="background-color: %s"></div>
+					<span class="speaker-name">%s</span>
+					%s
+				</div>
+				<div class="segment-header-right">
+					<div class="segment-time">
+						%s - %s
+					</div>
+					<div class="segment-actions">
+						<button class="segment-action-btn" data-action="copy" title="Copy text">
+							<i class="fas fa-copy"></i>
+						</button>
+						<button class="segment-action-btn" data-action="loop" title="Loop this segment">
+							<i class="fas fa-redo"></i>
+						</button>
+						<button class="segment-action-btn" data-action="edit-timing" title="Edit timing">
+							<i class="fas fa-clock"></i>
+						</button>
+						<button class="segment-action-btn" data-action="reassign" title="Reassign speaker">
+							<i class="fas fa-user-edit"></i>
+						</button>
+						<button class="segment-action-btn" data-action="delete" title="Delete segment">
+							<i class="fas fa-trash-alt"></i>
+						</button>
+					</div>
+				</div>
+			</div>
+			<div class="segment-nudge">
+				<span>START</span>
+				<button class="segment-action-btn" data-action="nudge" data-field="start" data-delta="-1">-1s</button>
+				<button class="segment-action-btn" data-action="nudge" data-field="start" data-delta="-0.1">-.1</button>
+				<button class="segment-action-btn" data-action="nudge" data-field="start" data-delta="0.1">+.1</button>
+				<button class="segment-action-btn" data-action="nudge" data-field="start" data-delta="1">+1s</button>
+				<span>END</span>
+				<button class="segment-action-btn" data-action="nudge" data-field="end" data-delta="-1">-1s</button>
+				<button class="segment-action-btn" data-action="nudge" data-field="end" data-delta="-0.1">-.1</button>
+				<button class="segment-action-btn" data-action="nudge" data-field="end" data-delta="0.1">+.1</button>
+				<button class="segment-action-btn" data-action="nudge" data-field="end" data-delta="1">+1s</button>
+			</div>
+			%s
+		</div>
+		`, app.loopClass(segment.Start, segment.End), confidenceClass, segment.Start, segment.End, i, htmlEscape(segment.Speaker), rowStyle, i, checked, speakerColor, htmlEscape(segment.Speaker), confidenceIcon,
+		app.formatSegmentTime(segment.Start), app.formatSegmentTime(segment.End), app.renderSegmentText(segment.Text))
+}
+
+func (app *AudioPipeApp) renderMergeDivider(i int) string {
+	if app.isConsolidated && len(app.consolidatedData) > 0 {
+		return ""
+	}
+
+	segments := app.transcriptionData.Segments
+	if i+1 >= len(segments) || segments[i].Speaker != segments[i+1].Speaker {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+		<div class="segment-merge-divider">
+			<button class="segment-action-btn" data-action="merge" data-index="%d" title="Merge with the next segment">
+				<i class="fas fa-compress-alt"></i> merge
+			</button>
+		</div>
+	`, i)
+}
+
+func (app *AudioPipeApp) matchingRows(queryLower string) []int {
+	rows := []int{}
+	for i := 0; i < app.timelineRowCount(); i++ {
+		if !app.rowPassesFilters(i) {
+			continue
+		}
+		var speaker, text string
+		if app.isConsolidated && len(app.consolidatedData) > 0 {
+			speaker, text = app.consolidatedData[i].Speaker, app.consolidatedData[i].Text
+		} else {
+			speaker, text = app.transcriptionData.Segments[i].Speaker, app.transcriptionData.Segments[i].Text
+		}
+		if matchesQuery(speaker, text, queryLower) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+func (app *AudioPipeApp) segmentConfidence(segment Segment) float64 {
+	if segment.Confidence == nil {
+		return 1.0
+	}
+	return *segment.Confidence
+}
+
+func (app *AudioPipeApp) isLowConfidence(segment Segment) bool {
+	return app.segmentConfidence(segment) < app.lowConfidenceThreshold
+}
+
+func (app *AudioPipeApp) rowFiltersActive() bool {
+	return app.lowConfidenceOnly || app.speakerFilter != "" || len(app.soloedSpeakers) > 0 || len(app.mutedSpeakers) > 0
+}
+
+func (app *AudioPipeApp) rowPassesFilters(i int) bool {
+	if app.isConsolidated && len(app.consolidatedData) > 0 {
+		speaker := app.consolidatedData[i].Speaker
+		return (app.speakerFilter == "" || speaker == app.speakerFilter) && speakerShown(speaker, app.soloedSpeakers, app.mutedSpeakers)
+	}
+
+	segment := app.transcriptionData.Segments[i]
+	if app.speakerFilter != "" && segment.Speaker != app.speakerFilter {
+		return false
+	}
+	if !speakerShown(segment.Speaker, app.soloedSpeakers, app.mutedSpeakers) {
+		return false
+	}
+	return !app.lowConfidenceOnly || app.isLowConfidence(segment)
+}
+
+func (app *AudioPipeApp) visibleRowCount() int {
+	if app.visibleRows != nil {
+		return len(app.visibleRows)
+	}
+	return app.timelineRowCount()
+}
+
+func (app *AudioPipeApp) visibleRowAt(pos int) int {
+	if app.visibleRows != nil {
+		return app.visibleRows[pos]
+	}
+	return pos
+}
+
+func (app *AudioPipeApp) timelineViewport(container js.Value) (float64, float64) {
+	top := container.Get("scrollTop").Float()
+	height := container.Get("clientHeight").Float()
+
+	viewport := container.Call("closest", ".terminal-viewport")
+	if container.Get("scrollHeight").Float() <= height+1 && !viewport.IsNull() {
+		rect := container.Call("getBoundingClientRect")
+		viewportRect := viewport.Call("getBoundingClientRect")
+		top = math.Max(0, viewportRect.Get("top").Float()-rect.Get("top").Float())
+		height = viewportRect.Get("height").Float()
+	}
+
+	return top, height
+}
+
+func (app *AudioPipeApp) renderVirtualWindow(force bool) {
+	container := js.Global().Get("document").Call("getElementById", "transcription-content")
+	if container.IsNull() || app.transcriptionData == nil {
+		return
+	}
+
+	count := app.visibleRowCount()
+	rowHeight := app.timelineRowHeight
+	visibleTop, visibleHeight := app.timelineViewport(container)
+
+	first := int(visibleTop/rowHeight) - virtualBufferRows
+	if first < 0 {
+		first = 0
+	}
+	if first > count {
+		first = count
+	}
+	last := int((visibleTop+visibleHeight)/rowHeight) + virtualBufferRows
+	if last > count {
+		last = count
+	}
+	if last < first {
+		last = first
+	}
+
+	if !force && first == app.virtualStart && last == app.virtualEnd {
+		return
+	}
+	app.virtualStart, app.virtualEnd = first, last
+
+	var htmlBuilder strings.Builder
+	htmlBuilder.WriteString(fmt.Sprintf(`<div class="virtual-spacer" style="height: %.0fpx"></div>`, float64(first)*rowHeight))
+	for pos := first; pos < last; pos++ {
+		row := app.visibleRowAt(pos)
+		htmlBuilder.WriteString(app.renderTimelineRow(row))
+		if pos+1 < last && app.visibleRowAt(pos+1) == row+1 {
+			htmlBuilder.WriteString(app.renderMergeDivider(row))
+		}
+	}
+	htmlBuilder.WriteString(fmt.Sprintf(`<div class="virtual-spacer" style="height: %.0fpx"></div>`, float64(count-last)*rowHeight))
+	container.Set("innerHTML", htmlBuilder.String())
+
+	rows := container.Call("querySelectorAll", ".timeline-segment-item")
+	if n := rows.Length(); n > 0 {
+		firstRow, lastRow := rows.Index(0), rows.Index(n-1)
+		span := lastRow.Get("offsetTop").Float() + lastRow.Get("offsetHeight").Float() - firstRow.Get("offsetTop").Float()
+		if span > 0 {
+			app.timelineRowHeight = span / float64(n)
+		}
+	}
+
+	if query := strings.ToLower(strings.TrimSpace(app.searchQuery)); query != "" {
+		for k := 0; k < rows.Length(); k++ {
+			row := rows.Index(k)
+			row.Get("classList").Call("add", "search-match")
+			if first+k == app.activeMatch {
+				row.Get("classList").Call("add", "search-active")
+			}
+			app.highlightMatches(row, query)
+		}
+	}
+
+	if app.audioData != nil {
+		app.highlightCurrentSpeaker()
+	}
+}
+
+func (app *AudioPipeApp) scrollToRow(pos int) {
+	container := js.Global().Get("document").Call("getElementById", "transcription-content")
+	if container.IsNull() {
+		return
+	}
+
+	rowTop := float64(pos) * app.timelineRowHeight
+	viewport := container.Call("closest", ".terminal-viewport")
+	if container.Get("scrollHeight").Float() > container.Get("clientHeight").Float()+1 || viewport.IsNull() {
+		container.Set("scrollTop", rowTop-container.Get("clientHeight").Float()/2)
+	} else {
+		rect := container.Call("getBoundingClientRect")
+		viewportRect := viewport.Call("getBoundingClientRect")
+		offset := rect.Get("top").Float() + rowTop - viewportRect.Get("top").Float() - viewportRect.Get("height").Float()/2
+		viewport.Set("scrollTop", viewport.Get("scrollTop").Float()+offset)
+	}
+
+	app.renderVirtualWindow(true)
+
+	rows := container.Call("querySelectorAll", ".timeline-segment-item")
+	if k := pos - app.virtualStart; k >= 0 && k < rows.Length() {
+		rows.Index(k).Call("scrollIntoView", map[string]interface{}{"block": "center"})
+	}
+}
+
+func (app *AudioPipeApp) setupTimelineVirtualization() {
+	container := js.Global().Get("document").Call("getElementById", "transcription-content")
+	if container.IsNull() {
+		return
+	}
+
+	renderFrame := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		app.virtualFramePending = false
+		if app.virtualized && app.currentView == "timeline" {
+			app.renderVirtualWindow(false)
+		}
+		return nil
+	})
+
+	onScroll := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !app.virtualized || app.virtualFramePending {
+			return nil
+		}
+		app.virtualFramePending = true
+		js.Global().Call("requestAnimationFrame", renderFrame)
+		return nil
+	})
+
+	container.Call("addEventListener", "scroll", onScroll)
+	if viewport := container.Call("closest", ".terminal-viewport"); !viewport.IsNull() {
+		viewport.Call("addEventListener", "scroll", onScroll)
+	}
+}
+
+func (app *AudioPipeApp) renderSegmentText(text string) string {
+	if app.maxSegmentLength <= 0 || len([]rune(text)) <= app.maxSegmentLength {
+		return fmt.Sprintf(`<div class="segment-text">%s</div>`, htmlEscape(text))
+	}
+
+	return fmt.Sprintf(`
+		<div class="segment-text collapsed">%s</div>
+		<button class="show-more-btn" data-action="toggle-text">show more</button>
+	`, htmlEscape(text))
+}
+
+func (app *AudioPipeApp) handleTimelineClick(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	target := args[0].Get("target")
+	actionElement := target.Call("closest", "[data-action]")
+	if actionElement.IsNull() {
+		if !target.Call("closest", "input, button, select, label").IsNull() {
+			return nil
+		}
+		if js.Global().Call("getSelection").Call("toString").String() != "" {
+			return nil
+		}
+		row := target.Call("closest", ".timeline-segment-item")
+		if !row.IsNull() {
+			app.activateSegmentElement(row)
+		}
+		return nil
+	}
+
+	row := actionElement.Call("closest", ".timeline-segment-item")
+	index := -1
+	if !row.IsNull() {
+		if parsed, err := strconv.Atoi(row.Call("getAttribute", "data-index").String()); err == nil {
+			index = parsed
+		}
+	}
+
+	if actionElement.Call("getAttribute", "data-action").String() == "merge" {
+		if first, err := strconv.Atoi(actionElement.Call("getAttribute", "data-index").String()); err == nil {
+			app.mergeAdjacentSegments(js.Value{}, []js.Value{js.ValueOf(first), js.ValueOf(first + 1)})
+		}
+		return nil
+	}
+
+	switch actionElement.Call("getAttribute", "data-action").String() {
+	case "filter-speaker":
+		app.filterBySpeaker(js.Value{}, []js.Value{row.Call("getAttribute", "data-speaker")})
+	case "copy":
+		if block, err := strconv.Atoi(row.Call("getAttribute", "data-block").String()); err == nil {
+			index = block
+		}
+		app.copySegmentText(js.Value{}, []js.Value{js.ValueOf(index)})
+	case "seek-line":
+		app.activateSegmentElement(actionElement)
+	case "toggle-text":
+		text := actionElement.Get("previousElementSibling")
+		if text.IsNull() {
+			return nil
+		}
+		if text.Get("classList").Call("toggle", "collapsed").Bool() {
+			actionElement.Set("textContent", "show more")
+		} else {
+			actionElement.Set("textContent", "show less")
+		}
+	case "loop":
+		start, errS := strconv.ParseFloat(row.Call("getAttribute", "data-start").String(), 64)
+		end, errE := strconv.ParseFloat(row.Call("getAttribute", "data-end").String(), 64)
+		if errS != nil || errE != nil {
+			return nil
+		}
+		app.loopSegment(js.Value{}, []js.Value{js.ValueOf(start), js.ValueOf(end)})
+	case "edit-timing":
+		app.showTimingEditor(row, index)
+	case "save-timing":
+		startInput := row.Call("querySelector", ".timing-input[data-field='start']")
+		endInput := row.Call("querySelector", ".timing-input[data-field='end']")
+		if startInput.IsNull() || endInput.IsNull() {
+			return nil
+		}
+		start, errS := strconv.ParseFloat(startInput.Get("value").String(), 64)
+		end, errE := strconv.ParseFloat(endInput.Get("value").String(), 64)
+		if errS != nil || errE != nil {
+			app.showToast("Enter start and end times in seconds", "error")
+			return nil
+		}
+		app.setSegmentTiming(js.Value{}, []js.Value{js.ValueOf(index), js.ValueOf(start), js.ValueOf(end)})
+	case "cancel-timing":
+		app.renderTimeline()
+	case "reassign":
+		app.showSpeakerSelect(row, index)
+	case "delete":
+		app.deleteSegment(js.Value{}, []js.Value{js.ValueOf(index)})
+	case "nudge":
+		delta, err := strconv.ParseFloat(actionElement.Call("getAttribute", "data-delta").String(), 64)
+		if err != nil {
+			return nil
+		}
+		if actionElement.Call("getAttribute", "data-field").String() == "start" {
+			app.adjustSegmentTime(js.Value{}, []js.Value{js.ValueOf(index), js.ValueOf(delta), js.ValueOf(0)})
+		} else {
+			app.adjustSegmentTime(js.Value{}, []js.Value{js.ValueOf(index), js.ValueOf(0), js.ValueOf(delta)})
+		}
+	}
+
+	return nil
+}
+
+func (app *AudioPipeApp) showSpeakerSelect(row js.Value, index int) {
+	if row.IsNull() || app.transcriptionData == nil || index < 0 || index >= len(app.transcriptionData.Segments) {
+		return
+	}
+
+	nameElement := row.Call("querySelector", ".speaker-name")
+	if nameElement.IsNull() {
+		return
+	}
+
+	current := app.transcriptionData.Segments[index].Speaker
+	var optionsBuilder strings.Builder
+	for _, speaker := range app.getUniqueSpeakers() {
+		selected := ""
+		if speaker == current {
+			selected = " selected"
+		}
+		optionsBuilder.WriteString(fmt.Sprintf(`<option value="%s"%s>%s</option>`,
+			htmlEscape(speaker), selected, htmlEscape(speaker)))
+	}
+	optionsBuilder.WriteString(`<option value="__new__">+ new speaker...</option>`)
+
+	nameElement.Set("innerHTML", fmt.Sprintf(`<select class="speaker-reassign terminal-select" data-index="%d" title="Reassign speaker">%s</select>`,
+		index, optionsBuilder.String()))
+}
+
+func (app *AudioPipeApp) handleSpeakerReassign(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	target := args[0].Get("target")
+	if !target.Get("classList").Call("contains", "speaker-reassign").Bool() {
+		return nil
+	}
+
+	index, err := strconv.Atoi(target.Call("getAttribute", "data-index").String())
+	if err != nil {
+		log.Printf("handleSpeakerReassign: invalid index: %v", err)
+		return nil
+	}
+
+	speaker := target.Get("value").String()
+	if speaker == "__new__" {
+		name := js.Global().Call("prompt", "New speaker name")
+		if name.IsNull() || strings.TrimSpace(name.String()) == "" {
+			app.renderTimeline()
+			return nil
+		}
+		speaker = name.String()
+	}
+
+	return app.reassignSegmentSpeaker(js.Value{}, []js.Value{js.ValueOf(index), js.ValueOf(speaker)})
+}
+
+func (app *AudioPipeApp) reassignSegmentSpeaker(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 || app.transcriptionData == nil {
+		return nil
+	}
+
+	index := args[0].Int()
+	speaker := strings.TrimSpace(args[1].String())
+	if index < 0 || index >= len(app.transcriptionData.Segments) {
+		app.showToast("Segment not found", "error")
+		return nil
+	}
+	if speaker == "" {
+		app.showToast("Speaker name cannot be empty", "error")
+		return nil
+	}
+	if app.transcriptionData.Segments[index].Speaker == speaker {
+		app.renderTimeline()
+		return nil
+	}
+
+	app.pushUndo()
+	app.transcriptionData.Segments[index].Speaker = speaker
+	if _, ok := app.speakerColors[speaker]; !ok {
+		palette := app.speakerPaletteForTheme()
+		app.speakerColors[speaker] = palette[len(app.speakerColors)%len(palette)]
+	}
+	app.afterSegmentsChanged()
+
+	app.showToast(fmt.Sprintf("Segment %d reassigned to %s", index+1, speaker), "success")
+	return nil
+}
+
+func (app *AudioPipeApp) showTimingEditor(row js.Value, index int) {
+	if row.IsNull() || app.transcriptionData == nil || index < 0 || index >= len(app.transcriptionData.Segments) {
+		return
+	}
+
+	timeElement := row.Call("querySelector", ".segment-time")
+	if timeElement.IsNull() {
+		return
+	}
+
+	segment := app.transcriptionData.Segments[index]
+	timeElement.Set("innerHTML", fmt.Sprintf(`
+		<input type="number" class="timing-input" data-field="start" min="0" step="0.01" value="%.2f" title="Start (seconds)">
+		-
+		<input type="number" class="timing-input" data-field="end" min="0" step="0.01" value="%.2f" title="End (seconds)">
+		<button class="segment-action-btn" data-action="save-timing" title="Save timing"><i class="fas fa-check"></i></button>
+		<button class="segment-action-btn" data-action="cancel-timing" title="Cancel"><i class="fas fa-times"></i></button>
+	`, segment.Start, segment.End))
+}
+
+func (app *AudioPipeApp) setSegmentTiming(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 || app.transcriptionData == nil {
+		return nil
+	}
+
+	index := args[0].Int()
+	start := args[1].Float()
+	end := args[2].Float()
+
+	if index < 0 || index >= len(app.transcriptionData.Segments) {
+		app.showToast("Segment not found", "error")
+		return nil
+	}
+	if start < 0 || end < 0 {
+		app.showToast("Segment times cannot be negative", "error")
+		return nil
+	}
+	if start >= end {
+		app.showToast("Segment start must be before its end", "error")
+		return nil
+	}
+
+	app.pushUndo()
+	app.transcriptionData.Segments[index].Start = start
+	app.transcriptionData.Segments[index].End = end
+	app.afterSegmentsChanged()
+
+	app.showToast(fmt.Sprintf("Segment timing set to %s - %s", formatTime(start), formatTime(end)), "success")
+	return nil
+}
+
+func (app *AudioPipeApp) deleteSegment(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || app.transcriptionData == nil {
+		return nil
+	}
+
+	segments := app.transcriptionData.Segments
+	if len(segments) == 0 {
+		app.showToast("No segments to delete", "warning")
+		return nil
+	}
+
+	index := -1
+	if len(args) >= 2 {
+		start, end := args[0].Float(), args[1].Float()
+		for i, segment := range segments {
+			if math.Abs(segment.Start-start) < 0.01 && math.Abs(segment.End-end) < 0.01 {
+				index = i
+				break
+			}
+		}
+	} else {
+		index = args[0].Int()
+	}
+
+	if index < 0 || index >= len(segments) {
+		app.showToast("Segment not found", "error")
+		return nil
+	}
+
+	app.pushUndo()
+
+	updated := make([]Segment, 0, len(segments)-1)
+	updated = append(updated, segments[:index]...)
+	updated = append(updated, segments[index+1:]...)
+	app.transcriptionData.Segments = updated
+
+	app.selectedSegments = make(map[int]bool)
+	app.updateSelectionCount()
+	pruneSpeakerColors(app.speakerColors, app.transcriptionData.Segments)
+	app.afterSegmentsChanged()
+
+	app.showToast(fmt.Sprintf("Deleted segment %d", index+1), "success")
+	return nil
+}
+
+func (app *AudioPipeApp) mergeAdjacentSegments(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 || app.transcriptionData == nil {
+		return nil
+	}
+
+	first, second := args[0].Int(), args[1].Int()
+	if first > second {
+		first, second = second, first
+	}
+
+	segments := app.transcriptionData.Segments
+	if first < 0 || second >= len(segments) || second != first+1 {
+		app.showToast("Only adjacent segments can be merged", "error")
+		return nil
+	}
+
+	app.pushUndo()
+
+	merged := segments[first]
+	merged.End = segments[second].End
+	merged.Text = strings.TrimSpace(merged.Text + " " + segments[second].Text)
+	if segments[first].RawText != "" || segments[second].RawText != "" {
+		rawText := func(segment Segment) string {
+			if segment.RawText != "" {
+				return segment.RawText
+			}
+			return segment.Text
+		}
+		merged.RawText = rawText(segments[first]) + " " + rawText(segments[second])
+	}
+	merged.Words = append(append([]Word{}, segments[first].Words...), segments[second].Words...)
+
+	updated := make([]Segment, 0, len(segments)-1)
+	updated = append(updated, segments[:first]...)
+	updated = append(updated, merged)
+	updated = append(updated, segments[second+1:]...)
+	app.transcriptionData.Segments = updated
+
+	app.selectedSegments = make(map[int]bool)
+	app.updateSelectionCount()
+	app.afterSegmentsChanged()
+
+	app.showToast(fmt.Sprintf("Merged segments %d and %d", first+1, second+1), "success")
+	return nil
+}
+
+func (app *AudioPipeApp) adjustSegmentTime(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 || app.transcriptionData == nil {
+		return nil
+	}
+
+	index := args[0].Int()
+	if index < 0 || index >= len(app.transcriptionData.Segments) {
+		app.showToast("Segment not found", "error")
+		return nil
+	}
+
+	segment := app.transcriptionData.Segments[index]
+	start := math.Max(0, math.Round((segment.Start+args[1].Float())*1000)/1000)
+	end := math.Max(0, math.Round((segment.End+args[2].Float())*1000)/1000)
+	if start > end {
+		if args[1].Float() != 0 {
+			start = end
+		} else {
+			end = start
+		}
+	}
+
+	if start == segment.Start && end == segment.End {
+		return nil
+	}
+
+	app.pushUndo()
+	app.transcriptionData.Segments[index].Start = start
+	app.transcriptionData.Segments[index].End = end
+	app.afterSegmentsChanged()
+
+	log.Printf("Segment %d adjusted to %.3f - %.3f", index, start, end)
+	return nil
+}
+
+func (app *AudioPipeApp) pushUndo() {
+	if app.transcriptionData == nil {
+		return
+	}
+
+	snapshot := UndoSnapshot{
+		Segments:      make([]Segment, len(app.transcriptionData.Segments)),
+		SpeakerColors: make(map[string]string, len(app.speakerColors)),
+	}
+	copy(snapshot.Segments, app.transcriptionData.Segments)
+	for speaker, color := range app.speakerColors {
+		snapshot.SpeakerColors[speaker] = color
+	}
+
+	app.undoStack = append(app.undoStack, snapshot)
+	if len(app.undoStack) > maxUndoSteps {
+		app.undoStack = app.undoStack[len(app.undoStack)-maxUndoSteps:]
+	}
+	app.updateUndoButton()
+}
+
+func (app *AudioPipeApp) undoEdit(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil || len(app.undoStack) == 0 {
+		app.showToast("Nothing to undo", "info")
+		return nil
+	}
+
+	last := len(app.undoStack) - 1
+	snapshot := app.undoStack[last]
+	app.transcriptionData.Segments = snapshot.Segments
+	app.undoStack = app.undoStack[:last]
+	app.updateUndoButton()
+	app.selectedSegments = make(map[int]bool)
+	app.updateSelectionCount()
+	for speaker, color := range snapshot.SpeakerColors {
+		if _, ok := app.speakerColors[speaker]; !ok {
+			app.speakerColors[speaker] = color
+		}
+	}
+	pruneSpeakerColors(app.speakerColors, app.transcriptionData.Segments)
+
+	app.afterSegmentsChanged()
+	app.showToast("Edit undone", "success")
+	return nil
+}
+
+func (app *AudioPipeApp) updateUndoButton() {
+	undoBtn := js.Global().Get("document").Call("getElementById", "undo-edit")
+	if !undoBtn.IsNull() {
+		undoBtn.Set("disabled", len(app.undoStack) == 0)
+	}
+}
+
+func (app *AudioPipeApp) afterSegmentsChanged() {
+	app.selectedSegments = reorderSegments(app.transcriptionData.Segments, app.selectedSegments)
+	app.updateSelectionCount()
+	app.captionKey = ""
+	app.calculateStatistics()
+	app.updateStatistics()
+	app.renderThresholdPreview()
+
+	if app.isConsolidated {
+		app.consolidatedData = app.consolidateSegmentsByThreshold(app.activeConsolidationThreshold(), app.consolidationMaxDuration, app.consolidationMinWords)
+	}
+
+	app.saveSession()
+	app.refreshCurrentView()
+}
+
+func (app *AudioPipeApp) setMaxSegmentLength(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	length := args[0].Int()
+	if length < 0 {
+		length = 0
+	}
+	app.maxSegmentLength = length
+
+	if app.currentView == "timeline" && app.transcriptionData != nil {
+		app.renderTimeline()
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) renderSpeakerTimelines() {
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "speaker-waveforms")
+	if container.IsNull() {
+		return
+	}
+
+	app.renderTimelineRuler()
+	app.renderBookmarks()
+
+	if app.transcriptionData == nil {
+		container.Set("innerHTML", `
+			<div class="empty-results">
+				<h3>No transcription loaded</h3>
+				<p>Audio playback is available. This GitHub Pages viewer does not transcribe audio, so load a final_transcription.json file from AudioPipe to see speaker segments.</p>
+			</div>
+		`)
+		return
+	}
+
+	speakers := app.getUniqueSpeakers()
+	overlaps := app.detectOverlaps()
+	var htmlBuilder strings.Builder
+
+	segmentsBySpeaker := make(map[string][]Segment, len(speakers))
+	speakerDurations := make(map[string]float64, len(speakers))
+	totalSpeaking := 0.0
+	for _, speaker := range speakers {
+		segmentsBySpeaker[speaker] = app.getSegmentsForSpeaker(speaker)
+		speakerDurations[speaker] = app.getTotalDurationForSpeaker(segmentsBySpeaker[speaker])
+		totalSpeaking += speakerDurations[speaker]
+	}
+
+	for _, speaker := range speakers {
+		speakerSegments := segmentsBySpeaker[speaker]
+		speakerColor := app.speakerColors[speaker]
+
+		share := 0.0
+		if totalSpeaking > 0 {
+			share = speakerDurations[speaker] / totalSpeaking * 100
+		}
+
+		trackClass := ""
+		if !speakerShown(speaker, app.soloedSpeakers, app.mutedSpeakers) {
+			trackClass = " track-hidden"
+		}
+		muteClass, soloClass := "", ""
+		if app.mutedSpeakers[speaker] {
+			muteClass = " active"
+		}
+		if app.soloedSpeakers[speaker] {
+			soloClass = " active"
+		}
+
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<div class="speaker-waveform-track%s" data-speaker="%s">
+				<div class="speaker-waveform-header">
+					<div class="speaker-info" title="%s">
+						<div class="speaker-badge" style="background-color: %s"></div>
+						<span class="speaker-name">%s</span>
+						<button class="track-toggle%s" data-track-action="mute" data-speaker="%s" title="Mute: hide this speaker">M</button>
+						<button class="track-toggle%s" data-track-action="solo" data-speaker="%s" title="Solo: show only soloed speakers">S</button>
+					</div>
+					<div class="speaker-stats">
+						<span class="talk-share" title="%s of speaking time">
+							<span class="talk-share-bar"><span class="talk-share-fill" style="width: %.1f%%; background-color: %s"></span></span>
+							%.0f%%
+						</span>
+						<span>%d</span>
+					</div>
+				</div>
+				<div class="speaker-waveform-content">
+					<div class="speaker-waveform-track-display">
+						%s
+					</div>
+				</div>
+			</div>
+		`, trackClass, htmlEscape(speaker), htmlEscape(speakerLoadSummary(speaker, speakerSegments)), speakerColor, htmlEscape(speaker),
+			muteClass, htmlEscape(speaker), soloClass, htmlEscape(speaker),
+			app.formatDuration(speakerDurations[speaker]), share, speakerColor, share, len(speakerSegments),
+			app.renderProfessionalSpeakerSegmentBars(speaker, speakerSegments, speakerColor, overlaps)))
+	}
+
+	container.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) toggleTrackState(action, speaker string) {
+	states := app.mutedSpeakers
+	if action == "solo" {
+		states = app.soloedSpeakers
+	}
+
+	if states[speaker] {
+		delete(states, speaker)
+	} else {
+		states[speaker] = true
+	}
+
+	app.renderSpeakerTimelines()
+	app.rerenderFilteredTimeline()
+}
+
+func (app *AudioPipeApp) renderTimelineRuler() {
+	ruler := js.Global().Get("document").Call("getElementById", "timeline-ruler")
+	if ruler.IsNull() {
+		return
+	}
+
+	totalDuration := app.visualizationDuration()
+	if app.transcriptionData == nil || totalDuration <= 0 {
+		ruler.Set("innerHTML", "")
+		return
+	}
+
+	interval := rulerIntervals[len(rulerIntervals)-1]
+	for _, candidate := range rulerIntervals {
+		if totalDuration/candidate <= maxRulerTicks {
+			interval = candidate
+			break
+		}
+	}
+
+	var htmlBuilder strings.Builder
+	for t := 0.0; t <= totalDuration; t += interval {
+		htmlBuilder.WriteString(fmt.Sprintf(`<div class="ruler-tick" style="left: %.2f%%;"><span class="ruler-label">%s</span></div>`,
+			t/totalDuration*100, formatTime(t)))
+	}
+
+	ruler.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) setupBookmarks() {
+	document := js.Global().Get("document")
+	labelInput := document.Call("getElementById", "bookmark-label")
+
+	addFromInput := func() {
+		label := ""
+		if !labelInput.IsNull() {
+			label = labelInput.Get("value").String()
+			labelInput.Set("value", "")
+		}
+		app.addBookmark(js.Value{}, []js.Value{js.ValueOf(label)})
+	}
+
+	addBtn := document.Call("getElementById", "add-bookmark")
+	if !addBtn.IsNull() {
+		addBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			addFromInput()
+			return nil
+		}))
+	}
+
+	if !labelInput.IsNull() {
+		labelInput.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) > 0 && args[0].Get("key").String() == "Enter" {
+				addFromInput()
+			}
+			return nil
+		}))
+	}
+
+	for _, id := range []string{"bookmark-lane", "bookmarks-list"} {
+		container := document.Call("getElementById", id)
+		if !container.IsNull() {
+			container.Call("addEventListener", "click", js.FuncOf(app.handleBookmarkClick))
+		}
+	}
+}
+
+func (app *AudioPipeApp) addBookmark(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil && app.audioData == nil {
+		app.showToast("Load a transcription or audio file first", "warning")
+		return nil
+	}
+
+	label := ""
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		label = strings.TrimSpace(args[0].String())
+	}
+
+	app.insertBookmark(app.currentTime, label)
+	return nil
+}
+
+func (app *AudioPipeApp) insertBookmark(at float64, label string) {
+	index := sort.Search(len(app.bookmarks), func(i int) bool {
+		return app.bookmarks[i].Time > at
+	})
+	app.bookmarks = append(app.bookmarks, Bookmark{})
+	copy(app.bookmarks[index+1:], app.bookmarks[index:])
+	app.bookmarks[index] = Bookmark{Time: at, Label: label}
+
+	app.saveSession()
+	app.renderBookmarks()
+	app.showToast(fmt.Sprintf("Bookmarked %s", formatTime(at)), "success")
+}
+
+func (app *AudioPipeApp) removeBookmark(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	index := args[0].Int()
+	if index < 0 || index >= len(app.bookmarks) {
+		return nil
+	}
+
+	app.bookmarks = append(app.bookmarks[:index], app.bookmarks[index+1:]...)
+	app.saveSession()
+	app.renderBookmarks()
+	return nil
+}
+
+func (app *AudioPipeApp) handleBookmarkClick(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	target := args[0].Get("target")
+	item := target.Call("closest", "[data-bookmark]")
+	if item.IsNull() {
+		return nil
+	}
+
+	index, err := strconv.Atoi(item.Call("getAttribute", "data-bookmark").String())
+	if err != nil || index < 0 || index >= len(app.bookmarks) {
+		return nil
+	}
+
+	if !target.Call("closest", "[data-action='remove-bookmark']").IsNull() {
+		return app.removeBookmark(js.Value{}, []js.Value{js.ValueOf(index)})
+	}
+
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(app.bookmarks[index].Time)})
+	return nil
+}
+
+func (app *AudioPipeApp) renderBookmarks() {
+	document := js.Global().Get("document")
+
+	lane := document.Call("getElementById", "bookmark-lane")
+	if !lane.IsNull() {
+		var laneBuilder strings.Builder
+		totalDuration := app.visualizationDuration()
+		if totalDuration > 0 {
+			for i, bookmark := range app.bookmarks {
+				laneBuilder.WriteString(fmt.Sprintf(`<button class="bookmark-pin" data-bookmark="%d" style="left: %.2f%%;" title="%s %s"><i class="fas fa-bookmark"></i></button>`,
+					i, math.Min(bookmark.Time/totalDuration, 1)*100, formatTime(bookmark.Time), htmlEscape(bookmark.Label)))
+			}
+		}
+		lane.Set("innerHTML", laneBuilder.String())
+	}
+
+	list := document.Call("getElementById", "bookmarks-list")
+	if list.IsNull() {
+		return
+	}
+
+	if len(app.bookmarks) == 0 {
+		list.Set("innerHTML", `<div class="bookmarks-empty">No bookmarks yet. Press B during playback to add one.</div>`)
+		return
+	}
+
+	var listBuilder strings.Builder
+	for i, bookmark := range app.bookmarks {
+		listBuilder.WriteString(fmt.Sprintf(`
+			<div class="bookmark-item" data-bookmark="%d" title="Seek to %s">
+				<span class="bookmark-time">%s</span>
+				<span class="bookmark-label">%s</span>
+				<button class="bookmark-remove" data-action="remove-bookmark" title="Remove bookmark"><i class="fas fa-times"></i></button>
+			</div>
+		`, i, formatTime(bookmark.Time), app.formatSegmentTime(bookmark.Time), htmlEscape(bookmark.Label)))
+	}
+	list.Set("innerHTML", listBuilder.String())
+}
+
+func (app *AudioPipeApp) calculateActivity(buckets int) []float64 {
+	activity := make([]float64, buckets)
+	totalDuration := app.visualizationDuration()
+	if app.transcriptionData == nil || totalDuration <= 0 || buckets <= 0 {
+		return activity
+	}
+
+	bucketDuration := totalDuration / float64(buckets)
+	for _, segment := range app.transcriptionData.Segments {
+		first := int(segment.Start / bucketDuration)
+		last := int(segment.End / bucketDuration)
+		if last >= buckets {
+			last = buckets - 1
+		}
+
+		for b := first; b <= last && b >= 0; b++ {
+			bucketStart := float64(b) * bucketDuration
+			overlap := math.Min(segment.End, bucketStart+bucketDuration) - math.Max(segment.Start, bucketStart)
+			if overlap > 0 {
+				activity[b] += overlap / bucketDuration
+			}
+		}
+	}
+
+	return activity
+}
+
+func (app *AudioPipeApp) renderActivityStrip() {
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "activity-strip")
+	if container.IsNull() {
+		return
+	}
+
+	if app.transcriptionData == nil || app.visualizationDuration() <= 0 {
+		container.Set("innerHTML", "")
+		return
+	}
+
+	activity := app.calculateActivity(activityBucketCount)
+	maxActivity := 0.0
+	for _, value := range activity {
+		maxActivity = math.Max(maxActivity, value)
+	}
+
+	bucketDuration := app.visualizationDuration() / float64(activityBucketCount)
+	var htmlBuilder strings.Builder
+	for b, value := range activity {
+		intensity := 0.05
+		if maxActivity > 0 {
+			intensity = math.Max(intensity, value/maxActivity)
+		}
+
+		start := float64(b) * bucketDuration
+		htmlBuilder.WriteString(fmt.Sprintf(
+			`<div class="activity-bucket" data-start="%.2f" style="opacity: %.2f" title="%s - %s: %.0f%% speech"></div>`,
+			start, intensity, formatTime(start), formatTime(start+bucketDuration), value*100))
+	}
+
+	container.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) renderSpeakingOrderStrip() {
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "speaking-order-strip")
+	if container.IsNull() {
+		return
+	}
+
+	duration := app.visualizationDuration()
+	if app.transcriptionData == nil || duration <= 0 {
+		container.Set("innerHTML", "")
+		return
+	}
+
+	turns := consolidateByThreshold(app.sortedSegments(), math.Inf(1), 0, 0)
+	var htmlBuilder strings.Builder
+	for _, turn := range turns {
+		left := turn.Start / duration * 100
+		width := math.Max((turn.End-turn.Start)/duration*100, 0.2)
+		htmlBuilder.WriteString(fmt.Sprintf(
+			`<div class="speaking-turn" data-start="%.2f" style="left: %.3f%%; width: %.3f%%; background-color: %s" title="%s: %s - %s"></div>`,
+			turn.Start, left, width, app.speakerColors[turn.Speaker],
+			htmlEscape(turn.Speaker), formatTime(turn.Start), formatTime(turn.End)))
+	}
+
+	container.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) renderSpeakingTimeChart() {
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "speaking-chart")
+	if container.IsNull() {
+		return
+	}
+
+	if app.transcriptionData == nil {
+		container.Set("innerHTML", "")
+		return
+	}
+
+	speakers := app.getUniqueSpeakers()
+	durations := make([]float64, len(speakers))
+	total := 0.0
+	for i, speaker := range speakers {
+		durations[i] = app.getTotalDurationForSpeaker(app.getSegmentsForSpeaker(speaker))
+		total += durations[i]
+	}
+
+	if total <= 0 {
+		container.Set("innerHTML", "")
+		return
+	}
+
+	var svgBuilder strings.Builder
+	var legendBuilder strings.Builder
+	offset := 0.0
+	for i, speaker := range speakers {
+		percent := durations[i] / total * 100
+		svgBuilder.WriteString(fmt.Sprintf(`<circle cx="21" cy="21" r="15.9155" fill="transparent" stroke="%s" stroke-width="6" stroke-dasharray="%.3f %.3f" stroke-dashoffset="%.3f"><title>%s: %.1f%%</title></circle>`,
+			app.speakerColors[speaker], percent, 100-percent, 25-offset, htmlEscape(speaker), percent))
+		offset += percent
+
+		legendBuilder.WriteString(fmt.Sprintf(`
+			<div class="speaking-chart-legend-item">
+				<span class="speaker-badge" style="background-color: %s"></span>
+				<span class="speaker-name">%s</span>
+				<span>%.1f%%</span>
+			</div>
+		`, app.speakerColors[speaker], htmlEscape(speaker), percent))
+	}
+
+	container.Set("innerHTML", fmt.Sprintf(`
+		<svg class="speaking-chart-donut" viewBox="0 0 42 42" role="img" aria-label="Speaking time by speaker">%s</svg>
+		<div class="speaking-chart-legend">%s</div>
+	`, svgBuilder.String(), legendBuilder.String()))
+}
+
+func (app *AudioPipeApp) renderSpeakerStatistics() {
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "speaker-stats")
+	if container.IsNull() {
+		return
+	}
+
+	if app.transcriptionData == nil {
+		container.Set("innerHTML", "")
+		return
+	}
+
+	var htmlBuilder strings.Builder
+	htmlBuilder.WriteString(`
+		<div class="speaker-stats-row speaker-stats-heading">
+			<span>SPEAKER</span>
+			<span>TIME</span>
+			<span>SEGMENTS</span>
+			<span>WORDS</span>
+			<span>SHARE</span>
+			<span>AVG TURN</span>
+		</div>
+	`)
+
+	for _, stat := range app.calculateSpeakerStatistics() {
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<div class="speaker-stats-row">
+				<span class="speaker-info">
+					<span class="speaker-badge" style="background-color: %s"></span>
+					<span class="speaker-name">%s</span>
+					<input type="text" class="speaker-color-input" data-speaker="%s" value="%s" maxlength="7" spellcheck="false" title="Speaker color (#RRGGBB)">
+				</span>
+				<span>%s</span>
+				<span>%d</span>
+				<span>%d</span>
+				<span>%.1f%%</span>
+				<span title="%d turns">%s</span>
+			</div>
+		`, app.speakerColors[stat.Speaker], htmlEscape(stat.Speaker), htmlEscape(stat.Speaker), app.speakerColors[stat.Speaker], app.formatDuration(stat.Duration),
+			stat.SegmentCount, stat.WordCount, stat.Percentage, stat.TurnCount, app.formatDuration(stat.AverageTurn)))
+	}
+
+	container.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) renderProfessionalSpeakerSegmentBars(speaker string, segments []Segment, speakerColor string, overlaps []Overlap) string {
+	if len(segments) == 0 {
+		return ""
+	}
+
+	totalDuration := app.visualizationDuration()
+	var htmlBuilder strings.Builder
+
+	htmlBuilder.WriteString(`<div class="clean-speaker-timeline" style="position: relative; height: 40px; background: var(--speaker-track-bg); border-radius: 4px; overflow: hidden;">`)
+
+	for _, gap := range app.silenceGaps {
+		htmlBuilder.WriteString(fmt.Sprintf(`<div class="silence-region" style="left: %.2f%%; width: %.2f%%;" title="Silence %s - %s (%s)"></div>`,
+			gap.Start/totalDuration*100, gap.Duration/totalDuration*100,
+			formatTime(gap.Start), formatTime(gap.End), app.formatDuration(gap.Duration)))
+	}
+
+	for i, segment := range segments {
+		startPercent := (segment.Start / totalDuration) * 100
+		widthPercent := ((segment.End - segment.Start) / totalDuration) * 100
+
+		overlapClass := ""
+		for _, overlap := range overlaps {
+			if (overlap.SpeakerA == speaker || overlap.SpeakerB == speaker) && overlap.Start < segment.End && overlap.End > segment.Start {
+				overlapClass = " overlapping"
+				break
+			}
+		}
+
+		barColor := speakerColor
+		if app.colorByConfidence {
+			barColor = confidenceColor(segment.Confidence)
+		}
+
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<div class="speaker-segment-bar%s"
+				 data-start="%.2f"
+				 data-end="%.2f"
+				 data-index="%d"
+				 style="left: %.2f%%; width: %.2f%%; background-color: %s;"
+				 title="%s: %s - %s&#10;%s">
+			</div>
+		`, overlapClass, segment.Start, segment.End, i, startPercent, widthPercent, barColor,
+			htmlEscape(speaker), app.formatSegmentTime(segment.Start), app.formatSegmentTime(segment.End), htmlEscape(segment.Text)))
+	}
+
+	htmlBuilder.WriteString(fmt.Sprintf(`<div class="track-playhead" style="left: %.2f%%;"></div>`, app.playheadPercent()))
+	htmlBuilder.WriteString("</div>")
+	return htmlBuilder.String()
+}
+
+func (app *AudioPipeApp) seekToPointer(element js.Value, clientX float64) {
+	totalDuration := app.visualizationDuration()
+	rect := element.Call("getBoundingClientRect")
+	width := rect.Get("width").Float()
+	if totalDuration <= 0 || width <= 0 {
+		return
+	}
+
+	ratio := (clientX - rect.Get("left").Float()) / width
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(math.Max(0, math.Min(ratio, 1)) * totalDuration)})
+}
+
+func (app *AudioPipeApp) addTapListener(element js.Value, onTap func(target js.Value, clientX float64)) {
+	tracking := false
+	var startX, startY float64
+
+	element.Call("addEventListener", "touchstart", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		touches := args[0].Get("touches")
+		tracking = touches.Length() == 1
+		if tracking {
+			startX = touches.Index(0).Get("clientX").Float()
+			startY = touches.Index(0).Get("clientY").Float()
+		}
+		return nil
+	}), map[string]interface{}{"passive": true})
+
+	element.Call("addEventListener", "touchcancel", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		tracking = false
+		return nil
+	}))
+
+	element.Call("addEventListener", "touchend", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !tracking {
+			return nil
+		}
+		tracking = false
+
+		touch := args[0].Get("changedTouches").Index(0)
+		clientX := touch.Get("clientX").Float()
+		if math.Abs(clientX-startX) > tapMoveTolerancePx || math.Abs(touch.Get("clientY").Float()-startY) > tapMoveTolerancePx {
+			return nil
+		}
+
+		args[0].Call("preventDefault")
+		onTap(args[0].Get("target"), clientX)
+		return nil
+	}))
+}
+
+func (app *AudioPipeApp) playheadPercent() float64 {
+	totalDuration := app.visualizationDuration()
+	if totalDuration <= 0 {
+		return 0
+	}
+	return math.Min(app.currentTime/totalDuration*100, 100)
+}
+
+func (app *AudioPipeApp) updateTrackPlayheads() {
+	if app.currentView != "visualization" {
+		return
+	}
+
+	playheads := js.Global().Get("document").Call("querySelectorAll", ".track-playhead")
+	left := fmt.Sprintf("%.2f%%", app.playheadPercent())
+	for i := 0; i < playheads.Length(); i++ {
+		playheads.Index(i).Get("style").Set("left", left)
+	}
+}
+
+func (app *AudioPipeApp) renderSpeakerSegmentBars(speaker string, segments []Segment) string {
+	if len(segments) == 0 {
+		return ""
+	}
+
+	totalDuration := app.visualizationDuration()
+	speakerColor := app.speakerColors[speaker]
+	var htmlBuilder strings.Builder
+
+	htmlBuilder.WriteString(`<div class="clean-speaker-timeline" style="position: relative; height: 35px; background: transparent; border-radius: 4px;">`)
+
+	for i, segment := range segments {
+		startPercent := (segment.Start / totalDuration) * 100
+		widthPercent := ((segment.End - segment.Start) / totalDuration) * 100
+
+		duration := segment.End - segment.Start
+		tooltipText := fmt.Sprintf("%s\n%s - %s (%s)\n\"%s\"",
+			speaker,
+			app.formatSegmentTime(segment.Start),
+			app.formatSegmentTime(segment.End),
+			app.formatDuration(duration),
+			segment.Text)
+
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<div class="speaker-segment-bar clickable-segment"
+				 data-start="%.2f"
+				 data-end="%.2f"
+				 data-speaker="%s"
+				 data-text="%s"
+				 data-index="%d"
+				 style="left: %.2f%%; width: %.2f%%; background-color: %s; cursor: pointer;"
+				 title="%s">
+			</div>
+		`, segment.Start, segment.End, htmlEscape(speaker), htmlEscape(segment.Text), i, startPercent, widthPercent, speakerColor, htmlEscape(tooltipText)))
+	}
+
+	htmlBuilder.WriteString("</div>")
+	return htmlBuilder.String()
+}
+
+func (app *AudioPipeApp) getSegmentsForSpeaker(speaker string) []Segment {
+	var segments []Segment
+	for _, segment := range app.transcriptionData.Segments {
+		if segment.Speaker == speaker {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func (app *AudioPipeApp) getTotalDurationForSpeaker(segments []Segment) float64 {
+	total := 0.0
+	for _, segment := range segments {
+		total += segment.End - segment.Start
+	}
+	return total
+}
+
+func (app *AudioPipeApp) handleSearchInput(this js.Value, args []js.Value) interface{} {
+	if len(args) > 0 {
+		query := args[0].Get("target").Get("value").String()
+		app.cancelPendingSearch()
+		if strings.TrimSpace(query) == "" {
+			app.handleSearch(js.Value{}, []js.Value{js.ValueOf(query)})
+			return nil
+		}
+		app.searchTimer = js.Global().Call("setTimeout", app.debouncedSearch, searchDebounceMs)
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) cancelPendingSearch() {
+	if !app.searchTimer.IsUndefined() {
+		js.Global().Call("clearTimeout", app.searchTimer)
+		app.searchTimer = js.Undefined()
+	}
+}
+
+func (app *AudioPipeApp) handleSearch(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := args[0].String()
+	app.searchQuery = query
+
+	document := js.Global().Get("document")
+	clearButton := document.Call("getElementById", "clear-search")
+
+	if strings.TrimSpace(query) != "" {
+		if !clearButton.IsNull() {
+			clearButton.Get("style").Set("display", "block")
+		}
+		app.filterTranscription(query)
+	} else {
+		if !clearButton.IsNull() {
+			clearButton.Get("style").Set("display", "none")
+		}
+		app.showAllSegments()
+	}
+
+	return nil
+}
+
+func (app *AudioPipeApp) clearSearch(this js.Value, args []js.Value) interface{} {
+	document := js.Global().Get("document")
+
+	searchInput := document.Call("getElementById", "search-input")
+	if !searchInput.IsNull() {
+		searchInput.Set("value", "")
+	}
+
+	clearButton := document.Call("getElementById", "clear-search")
+	if !clearButton.IsNull() {
+		clearButton.Get("style").Set("display", "none")
+	}
+
+	app.cancelPendingSearch()
+	app.searchQuery = ""
+	app.showAllSegments()
+	return nil
+}
+
+func (app *AudioPipeApp) filterTranscription(query string) {
+	if app.virtualized {
+		app.activeMatch = -1
+		app.hideNoResultsState()
+		app.showTimelineContent()
+		app.updateSearchCount(len(app.visibleRows))
+		if len(app.visibleRows) == 0 {
+			app.showNoResultsState()
+		}
+		return
+	}
+
+	document := js.Global().Get("document")
+	segments := document.Call("querySelectorAll", ".timeline-segment-item")
+	visibleCount := 0
+	queryLower := strings.ToLower(strings.TrimSpace(query))
+
+	matched := make(map[int]bool)
+	for _, row := range app.matchingRows(queryLower) {
+		matched[row] = true
+	}
+
+	for i := 0; i < segments.Length(); i++ {
+		segment := segments.Index(i)
+		attribute := "data-index"
+		if segment.Get("classList").Call("contains", "consolidated").Bool() {
+			attribute = "data-block"
+		}
+		row, err := strconv.Atoi(segment.Call("getAttribute", attribute).String())
+
+		segment.Get("classList").Call("remove", "search-active")
+		if err == nil && matched[row] {
+			segment.Get("style").Set("display", "block")
+			segment.Get("classList").Call("add", "search-match")
+			app.highlightMatches(segment, queryLower)
+			visibleCount++
+		} else {
+			segment.Get("style").Set("display", "none")
+			segment.Get("classList").Call("remove", "search-match")
+			app.clearHighlights(segment)
+		}
+	}
+
+	app.setMergeDividersVisible(false)
+	app.activeMatch = -1
+	app.updateSearchCount(visibleCount)
+
+	if visibleCount == 0 {
+		app.showNoResultsState()
+	}
+}
+
+func (app *AudioPipeApp) updateSearchCount(count int) {
+	document := js.Global().Get("document")
+
+	searchCount := document.Call("getElementById", "search-count")
+	if !searchCount.IsNull() {
+		switch {
+		case count < 0:
+			searchCount.Set("textContent", "")
+		case count == 1:
+			searchCount.Set("textContent", "1 match")
+		default:
+			searchCount.Set("textContent", fmt.Sprintf("%d matches", count))
+		}
+	}
+
+	searchNav := document.Call("getElementById", "search-nav")
+	if !searchNav.IsNull() {
+		if count > 0 {
+			searchNav.Get("style").Set("display", "flex")
+		} else {
+			searchNav.Get("style").Set("display", "none")
+		}
+	}
+}
+
+func (app *AudioPipeApp) searchNext(this js.Value, args []js.Value) interface{} {
+	app.stepSearchMatch(1)
+	return nil
+}
+
+func (app *AudioPipeApp) searchPrev(this js.Value, args []js.Value) interface{} {
+	app.stepSearchMatch(-1)
+	return nil
+}
+
+func (app *AudioPipeApp) stepSearchMatch(direction int) {
+	var matches js.Value
+	count := 0
+	if app.virtualized {
+		if strings.TrimSpace(app.searchQuery) != "" {
+			count = app.visibleRowCount()
+		}
+	} else {
+		matches = js.Global().Get("document").Call("querySelectorAll", ".timeline-segment-item.search-match")
+		count = matches.Length()
+	}
+	if count == 0 {
+		return
+	}
+
+	if !app.virtualized && app.activeMatch >= 0 && app.activeMatch < count {
+		matches.Index(app.activeMatch).Get("classList").Call("remove", "search-active")
+	}
+
+	if app.activeMatch < 0 && direction < 0 {
+		app.activeMatch = count - 1
+	} else {
+		app.activeMatch = ((app.activeMatch+direction)%count + count) % count
+	}
+
+	if app.virtualized {
+		app.scrollToRow(app.activeMatch)
+	} else {
+		active := matches.Index(app.activeMatch)
+		active.Get("classList").Call("add", "search-active")
+		active.Call("scrollIntoView", map[string]interface{}{"behavior": "smooth", "block": "center"})
+	}
+
+	searchCount := js.Global().Get("document").Call("getElementById", "search-count")
+	if !searchCount.IsNull() {
+		searchCount.Set("textContent", fmt.Sprintf("%d of %d", app.activeMatch+1, count))
+	}
+}
+
+func (app *AudioPipeApp) highlightMatches(segment js.Value, queryLower string) {
+	if queryLower == "" {
+		return
+	}
+
+	textElements := segment.Call("querySelectorAll", searchTextSelector)
+	for i := 0; i < textElements.Length(); i++ {
+		app.highlightText(textElements.Index(i), queryLower)
+	}
+}
+
+func (app *AudioPipeApp) highlightText(textElement js.Value, queryLower string) {
+	text := textElement.Get("textContent").String()
+	textLower := strings.ToLower(text)
+	if len(textLower) != len(text) {
+		// Case folding changed byte offsets; skip highlighting rather than misplace marks.
+		return
+	}
+
+	var htmlBuilder strings.Builder
+	matched := false
+	pos := 0
+	for {
+		idx := strings.Index(textLower[pos:], queryLower)
+		if idx < 0 {
+			break
+		}
+		start := pos + idx
+		end := start + len(queryLower)
+		htmlBuilder.WriteString(htmlEscape(text[pos:start]))
+		htmlBuilder.WriteString("<mark>")
+		htmlBuilder.WriteString(htmlEscape(text[start:end]))
+		htmlBuilder.WriteString("</mark>")
+		pos = end
+		matched = true
+	}
+
+	if !matched {
+		app.clearTextHighlights(textElement)
+		return
+	}
+
+	htmlBuilder.WriteString(htmlEscape(text[pos:]))
+	textElement.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) clearHighlights(segment js.Value) {
+	textElements := segment.Call("querySelectorAll", searchTextSelector)
+	for i := 0; i < textElements.Length(); i++ {
+		app.clearTextHighlights(textElements.Index(i))
+	}
+}
+
+func (app *AudioPipeApp) clearTextHighlights(textElement js.Value) {
+	if textElement.Call("querySelector", "mark").IsNull() {
+		return
+	}
+	textElement.Set("textContent", textElement.Get("textContent"))
+}
+
+func (app *AudioPipeApp) showAllSegments() {
+	if app.virtualized {
+		app.activeMatch = -1
+		app.updateSearchCount(-1)
+		app.hideNoResultsState()
+		app.showTimelineContent()
+		return
+	}
+
 	document := js.Global().Get("document")
-	welcomeState := document.Call("getElementById", "welcome-state")
-	if !welcomeState.IsNull() {
-		welcomeState.Get("style").Set("display", "block")
+	segments := document.Call("querySelectorAll", ".timeline-segment-item")
+
+	for i := 0; i < segments.Length(); i++ {
+		segment := segments.Index(i)
+		segment.Get("style").Set("display", "block")
+		segment.Get("classList").Call("remove", "search-match", "search-active")
+		app.clearHighlights(segment)
 	}
+
+	app.setMergeDividersVisible(true)
+	app.activeMatch = -1
+	app.updateSearchCount(-1)
+	app.hideNoResultsState()
 }
 
-func (app *AudioPipeApp) showLoadingState(message string) {
+func (app *AudioPipeApp) setMergeDividersVisible(visible bool) {
+	display := "none"
+	if visible {
+		display = ""
+	}
+
+	dividers := js.Global().Get("document").Call("querySelectorAll", ".segment-merge-divider")
+	for i := 0; i < dividers.Length(); i++ {
+		dividers.Index(i).Get("style").Set("display", display)
+	}
+}
+
+func (app *AudioPipeApp) hideNoResultsState() {
+	noResultsState := js.Global().Get("document").Call("getElementById", "no-results-state")
+	if !noResultsState.IsNull() {
+		noResultsState.Get("style").Set("display", "none")
+	}
+}
+
+func (app *AudioPipeApp) showNoResultsState() {
 	app.hideAllStates()
 	document := js.Global().Get("document")
-	loadingState := document.Call("getElementById", "loading-state")
-	if !loadingState.IsNull() {
-		loadingState.Get("style").Set("display", "block")
+	noResultsState := document.Call("getElementById", "no-results-state")
+	if !noResultsState.IsNull() {
+		noResultsState.Get("style").Set("display", "block")
+	}
+}
 
-		loadingMessage := document.Call("getElementById", "loading-message")
-		if !loadingMessage.IsNull() {
-			loadingMessage.Set("textContent", message)
-		}
+func (app *AudioPipeApp) exportAsText(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
+	}
+
+	message := "Transcription copied to clipboard"
+	if app.isConsolidated {
+		message = "Consolidated transcription copied to clipboard"
 	}
+
+	transcript, ok := app.textExport()
+	if !ok {
+		return nil
+	}
+
+	app.copyToClipboard(transcript, message)
+	return nil
 }
 
-func (app *AudioPipeApp) showTimelineView(this js.Value, args []js.Value) interface{} {
-	app.currentView = "timeline"
-	app.hideAllStates()
+func (app *AudioPipeApp) downloadAsText(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
+	}
 
-	if app.transcriptionData != nil {
-		app.showTimelineContent()
-		app.setActiveView("view-timeline")
-	} else {
-		app.showUploadState()
+	transcript, ok := app.textExport()
+	if !ok {
+		return nil
 	}
+
+	app.downloadFile("transcription.txt", transcript, "text/plain")
+	app.showToast("Text file downloaded", "success")
+
 	return nil
 }
 
-func (app *AudioPipeApp) showVisualizationView(this js.Value, args []js.Value) interface{} {
-	app.currentView = "visualization"
-	app.hideAllStates()
+func (app *AudioPipeApp) textExport() (string, bool) {
+	segments, ok := app.searchFilteredSegments(app.getViewSegments())
+	if !ok {
+		return "", false
+	}
+	return buildTextExport(segments), true
+}
 
-	if app.transcriptionData != nil || app.audioData != nil {
-		app.showVisualizationContent()
-		app.setActiveView("view-visualization")
+func (app *AudioPipeApp) copySegmentText(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || app.transcriptionData == nil {
+		return nil
+	}
+
+	index := args[0].Int()
+	if app.isConsolidated && len(app.consolidatedData) > 0 {
+		if index >= 0 && index < len(app.consolidatedData) {
+			app.copyToClipboard(app.consolidatedData[index].Text, "Block text copied to clipboard")
+		}
+		return nil
+	}
+
+	if index >= 0 && index < len(app.transcriptionData.Segments) {
+		app.copyToClipboard(app.transcriptionData.Segments[index].Text, "Segment text copied to clipboard")
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) copyToClipboard(text, successMessage string) {
+	navigator := js.Global().Get("navigator")
+	if !navigator.Get("clipboard").IsUndefined() {
+		navigator.Get("clipboard").Call("writeText", text).Call("then",
+			js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+				app.showToast(successMessage, "success")
+				return nil
+			})).Call("catch",
+			js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+				app.showToast("Failed to copy to clipboard", "error")
+				return nil
+			}))
 	} else {
-		app.showUploadState()
+		app.showToast("Clipboard API not supported", "error")
+	}
+}
+
+func (app *AudioPipeApp) exportAsSRT(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
+	}
+
+	segments, ok := app.searchFilteredSegments(app.getViewSegments())
+	if !ok {
+		return nil
 	}
+
+	segments, note := app.offsetSubtitles(segments)
+	app.downloadFile("transcription.srt", buildSRTExport(segments), "text/plain")
+	app.showToast("SRT file downloaded"+note, "success")
+
 	return nil
 }
 
-func (app *AudioPipeApp) showTimelineContent() {
-	document := js.Global().Get("document")
-	transcriptionContent := document.Call("getElementById", "transcription-content")
-	if !transcriptionContent.IsNull() {
-		transcriptionContent.Get("style").Set("display", "block")
-		app.renderTimeline()
+func (app *AudioPipeApp) exportAsVTT(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
+
+	segments, note := app.offsetSubtitles(app.getViewSegments())
+	app.downloadFile("transcription.vtt", buildVTTExport(segments), "text/vtt")
+	app.showToast("VTT file downloaded"+note, "success")
+
+	return nil
 }
 
-func (app *AudioPipeApp) showVisualizationContent() {
+func (app *AudioPipeApp) exportAsSubtitleSRT(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
+	}
+
 	document := js.Global().Get("document")
-	visualizationContent := document.Call("getElementById", "visualization-content")
-	if !visualizationContent.IsNull() {
-		visualizationContent.Get("style").Set("display", "block")
-		app.renderSpeakerTimelines()
+	maxChars := app.subtitleMaxChars
+	if input := document.Call("getElementById", "subtitle-max-chars"); !input.IsNull() {
+		if value, err := strconv.Atoi(input.Get("value").String()); err == nil && value > 0 {
+			maxChars = value
+		}
+	}
+	maxLines := app.subtitleMaxLines
+	if input := document.Call("getElementById", "subtitle-max-lines"); !input.IsNull() {
+		if value, err := strconv.Atoi(input.Get("value").String()); err == nil && value > 0 {
+			maxLines = value
+		}
 	}
+	app.subtitleMaxChars = maxChars
+	app.subtitleMaxLines = maxLines
+
+	segments, note := app.offsetSubtitles(app.getViewSegments())
+	cues := app.buildSubtitleCues(segments, maxChars, maxLines)
+
+	var srtBuilder strings.Builder
+	for i, cue := range cues {
+		srtBuilder.WriteString(fmt.Sprintf("%d\n%s --> %s\n%s\n\n",
+			i+1, formatSRTTime(cue.Start), formatSRTTime(cue.End), cue.Text))
+	}
+
+	app.downloadFile("transcription_subtitles.srt", srtBuilder.String(), "text/plain")
+	app.showToast(fmt.Sprintf("Subtitle SRT downloaded (%d cues, %d chars x %d lines)%s", len(cues), maxChars, maxLines, note), "success")
+
+	return nil
 }
 
-func (app *AudioPipeApp) setActiveView(activeButtonId string) {
-	document := js.Global().Get("document")
-	viewButtons := []string{"view-timeline", "view-speakers", "view-visualization"}
+func (app *AudioPipeApp) offsetSubtitles(segments []Segment) ([]Segment, string) {
+	input := js.Global().Get("document").Call("getElementById", "subtitle-offset")
+	if input.IsNull() {
+		return segments, ""
+	}
 
-	for _, buttonId := range viewButtons {
-		button := document.Call("getElementById", buttonId)
-		if !button.IsNull() {
-			if buttonId == activeButtonId {
-				button.Get("classList").Call("add", "active")
-			} else {
-				button.Get("classList").Call("remove", "active")
+	offset, err := strconv.ParseFloat(input.Get("value").String(), 64)
+	if err != nil || offset == 0 || math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return segments, ""
+	}
+
+	return applyTimeOffset(segments, offset), fmt.Sprintf(" (offset %+gs)", offset)
+}
+
+func (app *AudioPipeApp) buildSubtitleCues(segments []Segment, maxChars, maxLines int) []Segment {
+	var cues []Segment
+
+	for _, segment := range segments {
+		lines := wrapText(segment.Speaker+": "+segment.Text, maxChars)
+		if len(lines) == 0 {
+			continue
+		}
+
+		totalChars := 0
+		for _, line := range lines {
+			totalChars += len([]rune(line))
+		}
+
+		duration := segment.End - segment.Start
+		charsBefore := 0
+		for first := 0; first < len(lines); first += maxLines {
+			last := first + maxLines
+			if last > len(lines) {
+				last = len(lines)
+			}
+
+			cueChars := 0
+			for _, line := range lines[first:last] {
+				cueChars += len([]rune(line))
 			}
+
+			cues = append(cues, Segment{
+				Speaker: segment.Speaker,
+				Start:   segment.Start + duration*float64(charsBefore)/float64(totalChars),
+				End:     segment.Start + duration*float64(charsBefore+cueChars)/float64(totalChars),
+				Text:    strings.Join(lines[first:last], "\n"),
+			})
+			charsBefore += cueChars
+		}
+	}
+
+	return cues
+}
+
+func wrapText(text string, maxChars int) []string {
+	var lines []string
+	var current strings.Builder
+
+	for _, word := range strings.Fields(text) {
+		if current.Len() > 0 && len([]rune(current.String()))+1+len([]rune(word)) > maxChars {
+			lines = append(lines, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(word)
+	}
+
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
+
+func (app *AudioPipeApp) getViewSegments() []Segment {
+	segments, fellBack := viewSegments(app.transcriptionData.Segments, app.consolidatedData, app.isConsolidated)
+	if fellBack {
+		app.showToast("No consolidated segments, exporting original segments", "warning")
+	}
+	return segments
+}
+
+func (app *AudioPipeApp) searchFilteredSegments(segments []Segment) ([]Segment, bool) {
+	query := strings.TrimSpace(app.searchQuery)
+	if query == "" {
+		return segments, true
+	}
+
+	filtered := filterSegmentsByQuery(segments, query)
+	if len(filtered) == 0 {
+		app.showToast("No segments match the current search", "warning")
+		return nil, false
+	}
+
+	app.showToast(fmt.Sprintf("Exporting %d of %d segments (filtered)", len(filtered), len(segments)), "info")
+	return filtered, true
+}
+
+func (app *AudioPipeApp) consolidatedAsSegments() []Segment {
+	return blocksAsSegments(app.consolidatedData)
+}
+
+func (app *AudioPipeApp) exportAsCSV(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
+	}
+
+	segments, ok := app.searchFilteredSegments(app.transcriptionData.Segments)
+	if !ok {
+		return nil
+	}
+
+	app.downloadFile("transcription.csv", app.buildCSVExport(segments), "text/csv")
+	app.showToast("CSV file downloaded", "success")
+
+	return nil
+}
+
+func (app *AudioPipeApp) buildCSVExport(segments []Segment) string {
+	includeConfidence := false
+	for _, segment := range segments {
+		if segment.Confidence != nil {
+			includeConfidence = true
+			break
+		}
+	}
+
+	var csvBuilder strings.Builder
+	writer := csv.NewWriter(&csvBuilder)
+
+	header := []string{"speaker", "start", "end", "text"}
+	if includeConfidence {
+		header = append(header, "confidence")
+	}
+	writer.Write(header)
+
+	for _, segment := range segments {
+		row := []string{
+			segment.Speaker,
+			strconv.FormatFloat(segment.Start, 'f', 3, 64),
+			strconv.FormatFloat(segment.End, 'f', 3, 64),
+			segment.Text,
+		}
+		if includeConfidence {
+			confidence := ""
+			if segment.Confidence != nil {
+				confidence = strconv.FormatFloat(*segment.Confidence, 'f', 3, 64)
+			}
+			row = append(row, confidence)
 		}
+		writer.Write(row)
+	}
+
+	writer.Flush()
+	return csvBuilder.String()
+}
+
+func (app *AudioPipeApp) exportSpeakerStatsCSV(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
+
+	app.downloadFile("speaker_stats.csv", app.buildSpeakerStatsCSV(app.calculateSpeakerStatistics()), "text/csv")
+	app.showToast("Speaker statistics CSV downloaded", "success")
+
+	return nil
 }
 
-func (app *AudioPipeApp) updateStatistics() {
-	document := js.Global().Get("document")
+func (app *AudioPipeApp) buildSpeakerStatsCSV(stats []SpeakerStat) string {
+	var csvBuilder strings.Builder
+	writer := csv.NewWriter(&csvBuilder)
+
+	writer.Write([]string{"speaker", "total_seconds", "segment_count", "word_count", "percent_of_speech", "words_per_minute"})
+	for _, stat := range stats {
+		wordsPerMinute := 0.0
+		if stat.Duration > 0 {
+			wordsPerMinute = float64(stat.WordCount) / (stat.Duration / 60)
+		}
+		writer.Write([]string{
+			stat.Speaker,
+			strconv.FormatFloat(stat.Duration, 'f', 3, 64),
+			strconv.Itoa(stat.SegmentCount),
+			strconv.Itoa(stat.WordCount),
+			strconv.FormatFloat(stat.Percentage, 'f', 2, 64),
+			strconv.FormatFloat(wordsPerMinute, 'f', 1, 64),
+		})
+	}
+
+	writer.Flush()
+	return csvBuilder.String()
+}
 
-	segmentCount := document.Call("getElementById", "segment-count")
-	if !segmentCount.IsNull() {
-		segmentCount.Set("textContent", strconv.Itoa(app.statistics.SegmentCount))
+func (app *AudioPipeApp) exportLowConfidenceReport(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
 
-	speakerCount := document.Call("getElementById", "speaker-count")
-	if !speakerCount.IsNull() {
-		speakerCount.Set("textContent", strconv.Itoa(app.statistics.SpeakerCount))
-	}
+	var reportBuilder strings.Builder
+	scored := 0
+	flagged := 0
 
-	totalDuration := document.Call("getElementById", "total-duration")
-	if !totalDuration.IsNull() {
-		totalDuration.Set("textContent", app.formatTime(app.statistics.TotalDuration))
+	for _, segment := range app.transcriptionData.Segments {
+		if segment.Confidence == nil {
+			continue
+		}
+		scored++
+		if *segment.Confidence >= app.lowConfidenceThreshold {
+			continue
+		}
+		flagged++
+		reportBuilder.WriteString(fmt.Sprintf("[%s - %s] %s (confidence %.2f): %s\n\n",
+			formatTime(segment.Start), formatTime(segment.End),
+			segment.Speaker, *segment.Confidence, segment.Text))
 	}
 
-	wordCount := document.Call("getElementById", "word-count")
-	if !wordCount.IsNull() {
-		wordCount.Set("textContent", strconv.Itoa(app.statistics.WordCount))
+	if scored == 0 {
+		app.showToast("This transcription has no confidence scores", "warning")
+		return nil
+	}
+	if flagged == 0 {
+		app.showToast(fmt.Sprintf("No segments below %.2f confidence", app.lowConfidenceThreshold), "info")
+		return nil
 	}
-}
-
-func (app *AudioPipeApp) formatTime(seconds float64) string {
-	totalSecs := int(seconds)
-	mins := totalSecs / 60
-	secs := totalSecs % 60
-	return fmt.Sprintf("%d:%02d", mins, secs)
-}
 
-func (app *AudioPipeApp) formatSRTTime(seconds float64) string {
-	totalSecs := int(seconds)
-	hours := totalSecs / 3600
-	minutes := (totalSecs % 3600) / 60
-	secs := totalSecs % 60
-	ms := int((seconds - float64(totalSecs)) * 1000)
+	report := fmt.Sprintf("Low-confidence segments (below %.2f): %d of %d scored\n\n%s",
+		app.lowConfidenceThreshold, flagged, scored, reportBuilder.String())
+	app.downloadFile("low_confidence_report.txt", report, "text/plain")
+	app.showToast(fmt.Sprintf("Flagged %d low-confidence segments", flagged), "success")
 
-	return fmt.Sprintf("%02d:%02d:%02d,%03d", hours, minutes, secs, ms)
+	return nil
 }
 
-func (app *AudioPipeApp) toggleTheme(this js.Value, args []js.Value) interface{} {
-	body := js.Global().Get("document").Get("body")
-	localStorage := js.Global().Get("localStorage")
+func (app *AudioPipeApp) setLowConfidenceThreshold(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
 
-	if app.isDarkTheme {
-		body.Get("classList").Call("remove", "dark-theme")
-		localStorage.Call("setItem", "theme", "light")
-		app.isDarkTheme = false
-	} else {
-		body.Get("classList").Call("add", "dark-theme")
-		localStorage.Call("setItem", "theme", "dark")
-		app.isDarkTheme = true
+	threshold := args[0].Float()
+	if threshold < 0 {
+		threshold = 0
+	} else if threshold > 1 {
+		threshold = 1
 	}
+	app.lowConfidenceThreshold = threshold
+	app.rerenderFilteredTimeline()
 
-	app.updateThemeIcon()
 	return nil
 }
 
-func (app *AudioPipeApp) renderTimeline() {
-	if app.transcriptionData == nil {
-		return
+func (app *AudioPipeApp) toggleLowConfidenceOnly(this js.Value, args []js.Value) interface{} {
+	app.lowConfidenceOnly = !app.lowConfidenceOnly
+
+	button := js.Global().Get("document").Call("getElementById", "low-confidence-only")
+	if !button.IsNull() {
+		button.Get("classList").Call("toggle", "active", app.lowConfidenceOnly)
 	}
 
-	document := js.Global().Get("document")
-	container := document.Call("getElementById", "transcription-content")
-	if container.IsNull() {
-		return
+	if app.lowConfidenceOnly && app.isConsolidated {
+		app.showToast("The low-confidence filter applies to raw segments; reset consolidation to use it", "info")
 	}
 
-	var htmlBuilder strings.Builder
+	app.rerenderFilteredTimeline()
+	return nil
+}
 
-	if app.isConsolidated && len(app.consolidatedData) > 0 {
-		for _, segment := range app.consolidatedData {
-			speakerColor := app.speakerColors[segment.Speaker]
-
-			htmlBuilder.WriteString(fmt.Sprintf(`
-				<div class="timeline-segment-item consolidated" data-start="%.2f" data-end="%.2f">
-					<div class="segment-header">
-						<div class="speaker-info">
-							<div class="speaker-badge" style="background-color: %s"></div>
-							<span class="speaker-name">%s</span>
-						</div>
-						<div class="segment-time">
-							%s - %s
-						</div>
-					</div>
-					<div class="segment-text">%s</div>
-				</div>
-			`, segment.Start, segment.End, speakerColor, segment.Speaker,
-				app.formatTime(segment.Start), app.formatTime(segment.End), segment.Text))
-		}
-	} else {
-		for _, segment := range app.transcriptionData.Segments {
-			speakerColor := app.speakerColors[segment.Speaker]
-
-			htmlBuilder.WriteString(fmt.Sprintf(`
-				<div class="timeline-segment-item" data-start="%.2f" data-end="%.2f">
-					<div class="segment-header">
-						<div class="speaker-info">
-							<div class="speaker-badge" style="background-color: %s"></div>
-							<span class="speaker-name">%s</span>
-						</div>
-						<div class="segment-time">
-							%s - %s
-						</div>
-					</div>
-					<div class="segment-text">%s</div>
-				</div>
-			`, segment.Start, segment.End, speakerColor, segment.Speaker,
-				app.formatTime(segment.Start), app.formatTime(segment.End), segment.Text))
-		}
+func (app *AudioPipeApp) filterBySpeaker(this js.Value, args []js.Value) interface{} {
+	speaker := ""
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		speaker = args[0].String()
 	}
 
-	container.Set("innerHTML", htmlBuilder.String())
+	if speaker == app.speakerFilter {
+		speaker = ""
+	}
+	app.speakerFilter = speaker
+
+	app.rerenderFilteredTimeline()
+	app.updateSpeakerFilterStatus()
+	return nil
 }
 
-func (app *AudioPipeApp) renderSpeakerTimelines() {
-	document := js.Global().Get("document")
-	container := document.Call("getElementById", "speaker-waveforms")
-	if container.IsNull() {
+func (app *AudioPipeApp) updateSpeakerFilterStatus() {
+	status := js.Global().Get("document").Call("getElementById", "speaker-filter")
+	if status.IsNull() {
 		return
 	}
 
-	if app.transcriptionData == nil {
-		container.Set("innerHTML", `
-			<div class="empty-results">
-				<h3>No transcription loaded</h3>
-				<p>Audio playback is available. This GitHub Pages viewer does not transcribe audio, so load a final_transcription.json file from AudioPipe to see speaker segments.</p>
-			</div>
-		`)
+	if app.speakerFilter == "" || app.transcriptionData == nil {
+		status.Get("style").Set("display", "none")
 		return
 	}
 
-	speakers := app.getUniqueSpeakers()
-	var htmlBuilder strings.Builder
+	visible := 0
+	for i := 0; i < app.timelineRowCount(); i++ {
+		if app.rowPassesFilters(i) {
+			visible++
+		}
+	}
 
-	speakerColors := []string{
-		"#d4a574", // Golden/Orange - SPEAKER_00
-		"#7ba3d4", // Blue - SPEAKER_01
-		"#a47bd4", // Purple
-		"#74d4a5", // Green
-		"#d47474", // Red
-		"#d4d474", // Yellow
+	label := status.Call("querySelector", ".speaker-filter-label")
+	if !label.IsNull() {
+		label.Set("textContent", fmt.Sprintf("%s · %d of %d segments", app.speakerFilter, visible, app.timelineRowCount()))
 	}
+	status.Get("style").Set("display", "flex")
+}
 
-	for i, speaker := range speakers {
-		speakerSegments := app.getSegmentsForSpeaker(speaker)
+func (app *AudioPipeApp) renderSpeakerLegend() {
+	legend := js.Global().Get("document").Call("getElementById", "speaker-legend")
+	if legend.IsNull() || app.transcriptionData == nil {
+		return
+	}
 
-		colorIndex := i % len(speakerColors)
-		speakerColor := speakerColors[colorIndex]
+	var htmlBuilder strings.Builder
+	for _, speaker := range app.getUniqueSpeakers() {
+		stateClass, title := "", "Show only "+speaker
+		if app.speakerFilter == speaker {
+			stateClass, title = " active", "Show all speakers"
+		} else if app.speakerFilter != "" {
+			stateClass = " dimmed"
+		}
 
 		htmlBuilder.WriteString(fmt.Sprintf(`
-			<div class="speaker-waveform-track" data-speaker="%s">
-				<div class="speaker-waveform-header">
-					<div class="speaker-info">
-						<div class="speaker-badge speaker-%d" style="background-color: %s"></div>
-						<span class="speaker-name">%s</span>
-					</div>
-					<div class="speaker-stats">
-						<span>%d</span>
-					</div>
-				</div>
-				<div class="speaker-waveform-content">
-					<div class="speaker-waveform-track-display">
-						%s
-					</div>
-				</div>
-			</div>
-		`, speaker, colorIndex, speakerColor, speaker, len(speakerSegments),
-			app.renderProfessionalSpeakerSegmentBars(speaker, speakerSegments, colorIndex)))
+			<button class="legend-item%s" data-speaker="%s" title="%s">
+				<span class="speaker-badge" style="background-color: %s"></span>
+				<span class="legend-name">%s</span>
+			</button>
+		`, stateClass, htmlEscape(speaker), htmlEscape(title), app.speakerColors[speaker], htmlEscape(speaker)))
 	}
 
-	container.Set("innerHTML", htmlBuilder.String())
+	legend.Set("innerHTML", htmlBuilder.String())
 }
 
-func (app *AudioPipeApp) renderProfessionalSpeakerSegmentBars(speaker string, segments []Segment, colorIndex int) string {
-	if len(segments) == 0 {
-		return ""
+func (app *AudioPipeApp) rerenderFilteredTimeline() {
+	if app.currentView != "timeline" || app.transcriptionData == nil {
+		return
 	}
 
-	totalDuration := app.statistics.TotalDuration
-	var htmlBuilder strings.Builder
-
-	htmlBuilder.WriteString(`<div class="clean-speaker-timeline" style="position: relative; height: 40px; background: var(--speaker-track-bg); border-radius: 4px; overflow: hidden;">`)
-
-	for i, segment := range segments {
-		startPercent := (segment.Start / totalDuration) * 100
-		widthPercent := ((segment.End - segment.Start) / totalDuration) * 100
+	app.renderTimeline()
+	if strings.TrimSpace(app.searchQuery) != "" {
+		app.filterTranscription(app.searchQuery)
+	}
+}
 
-		htmlBuilder.WriteString(fmt.Sprintf(`
-			<div class="speaker-segment-bar speaker-%d"
-				 data-start="%.2f"
-				 data-end="%.2f"
-				 data-index="%d"
-				 style="left: %.2f%%; width: %.2f%%;"
-				 title="%s: %s - %s&#10;%s">
-			</div>
-		`, colorIndex, segment.Start, segment.End, i, startPercent, widthPercent,
-			speaker, app.formatTime(segment.Start), app.formatTime(segment.End), segment.Text))
+func (app *AudioPipeApp) getSelectedSegments() []Segment {
+	if app.transcriptionData == nil {
+		return []Segment{}
 	}
 
-	htmlBuilder.WriteString("</div>")
-	return htmlBuilder.String()
+	selected := make([]Segment, 0, len(app.selectedSegments))
+	for i, segment := range app.transcriptionData.Segments {
+		if app.selectedSegments[i] {
+			selected = append(selected, segment)
+		}
+	}
+	return selected
 }
 
-func (app *AudioPipeApp) renderSpeakerSegmentBars(speaker string, segments []Segment) string {
-	if len(segments) == 0 {
-		return ""
+func (app *AudioPipeApp) exportSelected(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
 
-	totalDuration := app.statistics.TotalDuration
-	speakerColor := app.speakerColors[speaker]
-	var htmlBuilder strings.Builder
+	format := "text"
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		format = args[0].String()
+	} else {
+		formatSelect := js.Global().Get("document").Call("getElementById", "export-selected-format")
+		if !formatSelect.IsNull() {
+			format = formatSelect.Get("value").String()
+		}
+	}
 
-	htmlBuilder.WriteString(`<div class="clean-speaker-timeline" style="position: relative; height: 35px; background: transparent; border-radius: 4px;">`)
+	selected := app.getSelectedSegments()
+	if len(selected) == 0 {
+		app.showToast("No segments selected", "warning")
+		return nil
+	}
 
-	for i, segment := range segments {
-		startPercent := (segment.Start / totalDuration) * 100
-		widthPercent := ((segment.End - segment.Start) / totalDuration) * 100
+	switch format {
+	case "csv":
+		app.downloadFile("selected_segments.csv", app.buildCSVExport(selected), "text/csv")
+		app.showToast(fmt.Sprintf("Exported %d selected segments as CSV", len(selected)), "success")
+	case "srt":
+		app.downloadFile("selected_segments.srt", buildSRTExport(selected), "text/plain")
+		app.showToast(fmt.Sprintf("Exported %d selected segments as SRT", len(selected)), "success")
+	case "json":
+		jsonData, err := json.MarshalIndent(TranscriptionData{Segments: selected}, "", "  ")
+		if err != nil {
+			app.showToast("Failed to generate JSON", "error")
+			return nil
+		}
+		app.downloadFile("selected_segments.json", string(jsonData), "application/json")
+		app.showToast(fmt.Sprintf("Exported %d selected segments as JSON", len(selected)), "success")
+	default:
+		app.copyToClipboard(buildTextExport(selected),
+			fmt.Sprintf("Copied %d selected segments to clipboard", len(selected)))
+	}
 
-		duration := segment.End - segment.Start
-		tooltipText := fmt.Sprintf("%s\n%s - %s (%.1fs)\n\"%s\"",
-			speaker,
-			app.formatTime(segment.Start),
-			app.formatTime(segment.End),
-			duration,
-			segment.Text)
+	return nil
+}
 
-		htmlBuilder.WriteString(fmt.Sprintf(`
-			<div class="speaker-segment-bar clickable-segment"
-				 data-start="%.2f"
-				 data-end="%.2f"
-				 data-speaker="%s"
-				 data-text="%s"
-				 data-index="%d"
-				 style="left: %.2f%%; width: %.2f%%; background-color: %s; cursor: pointer;"
-				 title="%s"
-				 onclick="seekToTime(null, [%.2f])">
-			</div>
-		`, segment.Start, segment.End, speaker, segment.Text, i, startPercent, widthPercent, speakerColor, tooltipText, segment.Start))
+func (app *AudioPipeApp) exportRange(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
 
-	htmlBuilder.WriteString("</div>")
-	return htmlBuilder.String()
-}
+	if len(args) < 2 {
+		app.showToast("Export range needs a start and end time", "warning")
+		return nil
+	}
 
-func (app *AudioPipeApp) getSegmentsForSpeaker(speaker string) []Segment {
-	var segments []Segment
-	for _, segment := range app.transcriptionData.Segments {
-		if segment.Speaker == speaker {
-			segments = append(segments, segment)
-		}
+	format := "text"
+	if len(args) > 2 && args[2].Type() == js.TypeString {
+		format = args[2].String()
 	}
-	return segments
-}
 
-func (app *AudioPipeApp) getTotalDurationForSpeaker(segments []Segment) float64 {
-	total := 0.0
-	for _, segment := range segments {
-		total += segment.End - segment.Start
+	totalDuration := app.statistics.TotalDuration
+	start := math.Max(0, math.Min(args[0].Float(), totalDuration))
+	end := math.Max(0, math.Min(args[1].Float(), totalDuration))
+	if start >= end {
+		app.showToast("Range start must be before its end", "warning")
+		return nil
 	}
-	return total
-}
 
-func (app *AudioPipeApp) handleSearchInput(this js.Value, args []js.Value) interface{} {
-	if len(args) > 0 {
-		query := args[0].Get("target").Get("value").String()
-		app.handleSearch(js.Value{}, []js.Value{js.ValueOf(query)})
+	segments := segmentsInRange(app.transcriptionData.Segments, start, end)
+	if len(segments) == 0 {
+		app.showToast(fmt.Sprintf("No segments between %s and %s", formatTime(start), formatTime(end)), "warning")
+		return nil
+	}
+
+	baseName := fmt.Sprintf("transcription_%.0f-%.0fs", start, end)
+	switch format {
+	case "srt":
+		app.downloadFile(baseName+".srt", buildSRTExport(segments), "text/plain")
+	case "json":
+		jsonData, err := json.MarshalIndent(TranscriptionData{Segments: segments}, "", "  ")
+		if err != nil {
+			app.showToast("Failed to generate JSON", "error")
+			return nil
+		}
+		app.downloadFile(baseName+".json", string(jsonData), "application/json")
+	default:
+		format = "text"
+		app.downloadFile(baseName+".txt", buildTextExport(segments), "text/plain")
 	}
+
+	app.showToast(fmt.Sprintf("Exported %d segments from %s to %s as %s", len(segments), formatTime(start), formatTime(end), strings.ToUpper(format)), "success")
 	return nil
 }
 
-func (app *AudioPipeApp) handleSearch(this js.Value, args []js.Value) interface{} {
+func (app *AudioPipeApp) handleSegmentSelection(this js.Value, args []js.Value) interface{} {
 	if len(args) == 0 {
 		return nil
 	}
 
-	query := args[0].String()
-	app.searchQuery = query
+	target := args[0].Get("target")
+	if !target.Get("classList").Call("contains", "segment-select").Bool() {
+		return nil
+	}
 
-	document := js.Global().Get("document")
-	clearButton := document.Call("getElementById", "clear-search")
+	index, err := strconv.Atoi(target.Call("getAttribute", "data-index").String())
+	if err != nil {
+		log.Printf("handleSegmentSelection: invalid index: %v", err)
+		return nil
+	}
 
-	if strings.TrimSpace(query) != "" {
-		if !clearButton.IsNull() {
-			clearButton.Get("style").Set("display", "block")
-		}
-		app.filterTranscription(query)
+	if target.Get("checked").Bool() {
+		app.selectedSegments[index] = true
 	} else {
-		if !clearButton.IsNull() {
-			clearButton.Get("style").Set("display", "none")
-		}
-		app.showAllSegments()
+		delete(app.selectedSegments, index)
 	}
-
+	app.updateSelectionCount()
 	return nil
 }
 
-func (app *AudioPipeApp) clearSearch(this js.Value, args []js.Value) interface{} {
-	document := js.Global().Get("document")
+func (app *AudioPipeApp) updateSelectionCount() {
+	selectionCount := js.Global().Get("document").Call("getElementById", "selection-count")
+	if !selectionCount.IsNull() {
+		selectionCount.Set("textContent", strconv.Itoa(len(app.selectedSegments)))
+	}
+}
 
-	searchInput := document.Call("getElementById", "search-input")
-	if !searchInput.IsNull() {
-		searchInput.Set("value", "")
+func (app *AudioPipeApp) exportAsJSON(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
 
-	clearButton := document.Call("getElementById", "clear-search")
-	if !clearButton.IsNull() {
-		clearButton.Get("style").Set("display", "none")
+	jsonData, err := json.MarshalIndent(struct {
+		*TranscriptionData
+		Bookmarks []Bookmark `json:"bookmarks,omitempty"`
+	}{app.transcriptionData, app.bookmarks}, "", "  ")
+	if err != nil {
+		app.showToast("Failed to generate JSON", "error")
+		return nil
 	}
 
-	app.searchQuery = ""
-	app.showAllSegments()
+	app.downloadFile("transcription.json", string(jsonData), "application/json")
+	app.showToast("Transcription JSON downloaded", "success")
+
 	return nil
 }
 
-func (app *AudioPipeApp) filterTranscription(query string) {
-	document := js.Global().Get("document")
-	segments := document.Call("querySelectorAll", ".timeline-segment-item")
-	visibleCount := 0
-	queryLower := strings.ToLower(query)
-
-	for i := 0; i < segments.Length(); i++ {
-		segment := segments.Index(i)
-		text := strings.ToLower(segment.Get("textContent").String())
-
-		if strings.Contains(text, queryLower) {
-			segment.Get("style").Set("display", "block")
-			visibleCount++
-		} else {
-			segment.Get("style").Set("display", "none")
-		}
+func (app *AudioPipeApp) exportAsHTML(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
 
-	if visibleCount == 0 {
-		app.showNoResultsState()
-	}
+	app.downloadFile("transcription.html", app.buildHTMLExport(app.getViewSegments()), "text/html")
+	app.showToast("HTML transcript downloaded", "success")
+
+	return nil
 }
 
-func (app *AudioPipeApp) showAllSegments() {
-	document := js.Global().Get("document")
-	segments := document.Call("querySelectorAll", ".timeline-segment-item")
+func (app *AudioPipeApp) buildHTMLExport(segments []Segment) string {
+	var htmlBuilder strings.Builder
 
-	for i := 0; i < segments.Length(); i++ {
-		segment := segments.Index(i)
-		segment.Get("style").Set("display", "block")
+	htmlBuilder.WriteString(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>Transcription</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 800px; margin: 40px auto; padding: 0 20px; color: #1f2937; background: #ffffff; line-height: 1.6; }
+h1 { font-size: 1.4em; border-bottom: 1px solid #e5e7eb; padding-bottom: 8px; }
+.segment { margin: 16px 0; }
+.segment-header { display: flex; align-items: center; gap: 8px; font-size: 0.85em; color: #6b7280; }
+.speaker-badge { display: inline-block; width: 10px; height: 10px; border-radius: 50%; }
+.speaker-name { font-weight: bold; color: #111827; }
+.segment-text { margin-top: 4px; }
+</style>
+</head>
+<body>
+<h1>Transcription</h1>
+`)
+
+	for _, segment := range segments {
+		htmlBuilder.WriteString(fmt.Sprintf(`<div class="segment">
+<div class="segment-header"><span class="speaker-badge" style="background-color: %s"></span><span class="speaker-name">%s</span><span>%s - %s</span></div>
+<div class="segment-text">%s</div>
+</div>
+`, app.speakerColors[segment.Speaker], htmlEscape(segment.Speaker),
+			formatTime(segment.Start), formatTime(segment.End), htmlEscape(segment.Text)))
 	}
 
-	noResultsState := document.Call("getElementById", "no-results-state")
-	if !noResultsState.IsNull() {
-		noResultsState.Get("style").Set("display", "none")
+	htmlBuilder.WriteString("</body>\n</html>\n")
+	return htmlBuilder.String()
+}
+
+func (app *AudioPipeApp) exportAsWord(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data to export", "warning")
+		return nil
 	}
+
+	app.downloadFile("transcription.doc", app.buildWordExport(app.getViewSegments()), "application/msword")
+	app.showToast("Word transcript downloaded", "success")
+
+	return nil
 }
 
-func (app *AudioPipeApp) showNoResultsState() {
-	app.hideAllStates()
-	document := js.Global().Get("document")
-	noResultsState := document.Call("getElementById", "no-results-state")
-	if !noResultsState.IsNull() {
-		noResultsState.Get("style").Set("display", "block")
+func (app *AudioPipeApp) buildWordExport(segments []Segment) string {
+	const cellStyle = "border: 1px solid #9ca3af; padding: 4pt 6pt; vertical-align: top;"
+	var htmlBuilder strings.Builder
+
+	htmlBuilder.WriteString(`<html xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:w="urn:schemas-microsoft-com:office:word" xmlns="http://www.w3.org/TR/REC-html40">
+<head>
+<meta charset="UTF-8">
+<title>Transcription</title>
+</head>
+<body style="font-family: Calibri, Arial, sans-serif; font-size: 11pt;">
+<h1 style="font-size: 16pt;">Transcription</h1>
+<table style="border-collapse: collapse; width: 100%;">
+`)
+	htmlBuilder.WriteString(fmt.Sprintf(`<tr><th style="%[1]s background-color: #e5e7eb; text-align: left;">Time</th><th style="%[1]s background-color: #e5e7eb; text-align: left;">Speaker</th><th style="%[1]s background-color: #e5e7eb; text-align: left;">Text</th></tr>
+`, cellStyle))
+
+	for _, segment := range segments {
+		htmlBuilder.WriteString(fmt.Sprintf(`<tr><td style="%s white-space: nowrap;">%s - %s</td><td style="%s background-color: %s; font-weight: bold;">%s</td><td style="%s">%s</td></tr>
+`, cellStyle, formatTime(segment.Start), formatTime(segment.End),
+			cellStyle, htmlEscape(app.speakerColors[segment.Speaker]), htmlEscape(segment.Speaker),
+			cellStyle, htmlEscape(segment.Text)))
 	}
+
+	htmlBuilder.WriteString("</table>\n</body>\n</html>\n")
+	return htmlBuilder.String()
 }
 
-func (app *AudioPipeApp) exportAsText(this js.Value, args []js.Value) interface{} {
+func (app *AudioPipeApp) exportAsMarkdown(this js.Value, args []js.Value) interface{} {
 	if app.transcriptionData == nil {
 		app.showToast("No transcription data to export", "warning")
 		return nil
 	}
 
-	var textBuilder strings.Builder
+	app.downloadFile("transcription.md", app.buildMarkdownExport(app.getViewSegments()), "text/markdown")
+	app.showToast("Markdown transcript downloaded", "success")
 
-	for _, segment := range app.transcriptionData.Segments {
-		textBuilder.WriteString(fmt.Sprintf("[%s - %s] %s: %s\n\n",
-			app.formatTime(segment.Start), app.formatTime(segment.End),
-			segment.Speaker, segment.Text))
-	}
+	return nil
+}
 
-	navigator := js.Global().Get("navigator")
-	if !navigator.Get("clipboard").IsUndefined() {
-		navigator.Get("clipboard").Call("writeText", textBuilder.String()).Call("then",
-			js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-				app.showToast("Transcription copied to clipboard", "success")
-				return nil
-			})).Call("catch",
-			js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-				app.showToast("Failed to copy to clipboard", "error")
-				return nil
-			}))
-	} else {
-		app.showToast("Clipboard API not supported", "error")
+func (app *AudioPipeApp) buildMarkdownExport(segments []Segment) string {
+	var mdBuilder strings.Builder
+
+	for _, segment := range segments {
+		mdBuilder.WriteString(fmt.Sprintf("## [%s] %s\n\n%s\n\n",
+			formatTime(segment.Start), markdownEscaper.Replace(segment.Speaker),
+			markdownEscaper.Replace(strings.TrimSpace(segment.Text))))
 	}
 
-	return nil
+	return mdBuilder.String()
 }
 
-func (app *AudioPipeApp) exportAsSRT(this js.Value, args []js.Value) interface{} {
+func (app *AudioPipeApp) exportPerSpeakerZip(this js.Value, args []js.Value) interface{} {
 	if app.transcriptionData == nil {
 		app.showToast("No transcription data to export", "warning")
 		return nil
 	}
 
-	var srtBuilder strings.Builder
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	speakers := app.getUniqueSpeakers()
+	names := speakerFileNames(speakers)
 
-	for i, segment := range app.transcriptionData.Segments {
-		srtBuilder.WriteString(fmt.Sprintf("%d\n%s --> %s\n%s: %s\n\n",
-			i+1, app.formatSRTTime(segment.Start), app.formatSRTTime(segment.End),
-			segment.Speaker, segment.Text))
+	for i, speaker := range speakers {
+		entry, err := zipWriter.Create(names[i] + ".txt")
+		if err != nil {
+			app.showToast("Failed to build speaker ZIP", "error")
+			return nil
+		}
+		if _, err := entry.Write([]byte(buildTextExport(app.getSegmentsForSpeaker(speaker)))); err != nil {
+			app.showToast("Failed to build speaker ZIP", "error")
+			return nil
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		app.showToast("Failed to build speaker ZIP", "error")
+		return nil
 	}
 
-	app.downloadFile("transcription.srt", srtBuilder.String(), "text/plain")
-	app.showToast("SRT file downloaded", "success")
+	app.downloadFile("speakers.zip", buf.String(), "application/zip")
+	app.showToast(fmt.Sprintf("Downloaded transcripts for %d speakers", len(speakers)), "success")
 
 	return nil
 }
@@ -1014,6 +5308,32 @@ func (app *AudioPipeApp) downloadConsolidated(this js.Value, args []js.Value) in
 	return nil
 }
 
+func (app *AudioPipeApp) downloadConsolidatedSRT(this js.Value, args []js.Value) interface{} {
+	if len(app.consolidatedData) == 0 {
+		app.showToast("No consolidated segments to download", "warning")
+		return nil
+	}
+
+	segments, note := app.offsetSubtitles(app.consolidatedAsSegments())
+	app.downloadFile("consolidated_segments.srt", buildSRTExport(segments), "text/plain")
+	app.showToast("Consolidated SRT downloaded"+note, "success")
+
+	return nil
+}
+
+func (app *AudioPipeApp) downloadConsolidatedVTT(this js.Value, args []js.Value) interface{} {
+	if len(app.consolidatedData) == 0 {
+		app.showToast("No consolidated segments to download", "warning")
+		return nil
+	}
+
+	segments, note := app.offsetSubtitles(app.consolidatedAsSegments())
+	app.downloadFile("consolidated_segments.vtt", buildVTTExport(segments), "text/vtt")
+	app.showToast("Consolidated VTT downloaded"+note, "success")
+
+	return nil
+}
+
 func (app *AudioPipeApp) downloadFile(filename, content, mimeType string) {
 	uint8Array := js.Global().Get("Uint8Array").New(len(content))
 	js.CopyBytesToJS(uint8Array, []byte(content))
@@ -1049,57 +5369,81 @@ func (app *AudioPipeApp) consolidateSegments(this js.Value, args []js.Value) int
 		}
 	}
 
-	segments := make([]Segment, len(app.transcriptionData.Segments))
-	copy(segments, app.transcriptionData.Segments)
+	consolidated := app.consolidateSegmentsByThreshold(threshold, 0, 0)
+	app.consolidatedData = consolidated
 
-	sort.Slice(segments, func(i, j int) bool {
-		return segments[i].Start < segments[j].Start
-	})
+	app.showToast(fmt.Sprintf("Consolidated %d segments into %d groups",
+		len(app.transcriptionData.Segments), len(consolidated)), "success")
 
-	var consolidated []ConsolidatedSegment
-	var currentGroup *ConsolidatedSegment
+	return nil
+}
 
-	for _, segment := range segments {
-		if currentGroup == nil ||
-			currentGroup.Speaker != segment.Speaker ||
-			(segment.Start-currentGroup.End) > threshold {
+func (app *AudioPipeApp) showToast(message, toastType string) {
+	toast := app.newToast(message, toastType)
+	js.Global().Get("document").Get("body").Call("appendChild", toast)
 
-			if currentGroup != nil {
-				consolidated = append(consolidated, *currentGroup)
-			}
+	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !toast.Get("parentNode").IsNull() {
+			toast.Get("parentNode").Call("removeChild", toast)
+		}
+		return nil
+	}), 3000)
 
-			currentGroup = &ConsolidatedSegment{
-				Speaker:   segment.Speaker,
-				Start:     segment.Start,
-				End:       segment.End,
-				Text:      segment.Text,
-				Segments:  []Segment{segment},
-				WordCount: len(strings.Fields(segment.Text)),
-			}
-		} else {
-			currentGroup.End = segment.End
-			currentGroup.Text += " " + segment.Text
-			currentGroup.Segments = append(currentGroup.Segments, segment)
-			currentGroup.WordCount += len(strings.Fields(segment.Text))
+	log.Printf("Toast [%s]: %s", toastType, message)
+}
+
+func (app *AudioPipeApp) showConfirmToast(message, confirmLabel string, onConfirm, onDismiss func()) {
+	document := js.Global().Get("document")
+	toast := app.newToast(message, "info")
+
+	settled := false
+	settle := func(action func()) {
+		if settled {
+			return
+		}
+		settled = true
+		if !toast.Get("parentNode").IsNull() {
+			toast.Get("parentNode").Call("removeChild", toast)
+		}
+		if action != nil {
+			action()
 		}
 	}
 
-	if currentGroup != nil {
-		consolidated = append(consolidated, *currentGroup)
-	}
+	actions := document.Call("createElement", "div")
+	actions.Set("className", "toast-actions")
 
-	app.consolidatedData = consolidated
+	confirmBtn := document.Call("createElement", "button")
+	confirmBtn.Set("className", "terminal-btn")
+	confirmBtn.Set("textContent", confirmLabel)
+	confirmBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		settle(onConfirm)
+		return nil
+	}))
 
-	app.showToast(fmt.Sprintf("Consolidated %d segments into %d groups",
-		len(segments), len(consolidated)), "success")
+	dismissBtn := document.Call("createElement", "button")
+	dismissBtn.Set("className", "terminal-btn")
+	dismissBtn.Set("textContent", "DISMISS")
+	dismissBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		settle(onDismiss)
+		return nil
+	}))
 
-	return nil
-}
+	actions.Call("appendChild", confirmBtn)
+	actions.Call("appendChild", dismissBtn)
+	toast.Call("appendChild", actions)
+	document.Get("body").Call("appendChild", toast)
 
-func (app *AudioPipeApp) showToast(message, toastType string) {
-	document := js.Global().Get("document")
+	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		settle(onDismiss)
+		return nil
+	}), confirmToastTimeoutMs)
+
+	log.Printf("Confirm toast: %s", message)
+}
 
-	toast := document.Call("createElement", "div")
+func (app *AudioPipeApp) newToast(message, toastType string) js.Value {
+	toast := js.Global().Get("document").Call("createElement", "div")
 	toast.Set("className", fmt.Sprintf("toast toast-%s", toastType))
 	toast.Set("textContent", message)
 
@@ -1125,16 +5469,7 @@ func (app *AudioPipeApp) showToast(message, toastType string) {
 		style.Set("backgroundColor", "#3b82f6")
 	}
 
-	document.Get("body").Call("appendChild", toast)
-
-	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		if !toast.Get("parentNode").IsNull() {
-			toast.Get("parentNode").Call("removeChild", toast)
-		}
-		return nil
-	}), 3000)
-
-	log.Printf("Toast [%s]: %s", toastType, message)
+	return toast
 }
 
 func (app *AudioPipeApp) isValidAudioFormat(fileName, mimeType string) bool {
@@ -1203,6 +5538,25 @@ func (app *AudioPipeApp) updateLoadingMessage(message string) {
 	}
 }
 
+func (app *AudioPipeApp) updateLoadingProgress(percent float64) {
+	document := js.Global().Get("document")
+	track := document.Call("getElementById", "loading-progress")
+	bar := document.Call("getElementById", "loading-progress-bar")
+	if track.IsNull() || bar.IsNull() {
+		return
+	}
+
+	bar.Get("style").Set("width", fmt.Sprintf("%.1f%%", math.Max(0, math.Min(percent, 100))))
+	track.Get("style").Set("display", "block")
+}
+
+func (app *AudioPipeApp) hideLoadingProgress() {
+	track := js.Global().Get("document").Call("getElementById", "loading-progress")
+	if !track.IsNull() {
+		track.Get("style").Set("display", "none")
+	}
+}
+
 func (app *AudioPipeApp) handleAudioInput(this js.Value, args []js.Value) interface{} {
 	if len(args) > 0 {
 		files := args[0].Get("target").Get("files")
@@ -1247,12 +5601,24 @@ func (app *AudioPipeApp) processAudioFile(file js.Value) {
 	}
 	log.Printf("✅ AUDIO SIZE VALIDATION PASSED: %.1f MB is within %d MB limit", fileSizeMB, maxSizeMB)
 
+	app.clearLoop()
+
 	log.Printf("🌊 INITIALIZING WAVESURFER.JS...")
 	app.initializeWaveSurfer(file, fileName)
 }
 
 func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
-	log.Printf("🌊 WAVESURFER INITIALIZATION STARTED...")
+	const maxMediaElementMB = 50.0
+
+	backend := "WebAudio"
+	if bytesToMB(file.Get("size").Float()) > maxMediaElementMB {
+		backend = "MediaElement"
+	}
+	app.createWaveSurfer(file, fileName, backend)
+}
+
+func (app *AudioPipeApp) createWaveSurfer(file js.Value, fileName, backend string) {
+	log.Printf("🌊 WAVESURFER INITIALIZATION STARTED (%s backend)...", backend)
 
 	waveSurferGlobal := js.Global().Get("WaveSurfer")
 	if waveSurferGlobal.IsUndefined() {
@@ -1283,50 +5649,28 @@ func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
 		containerWidth = 800
 	}
 
-	maxPixelsPerSecond := 2.0
-
-	const maxMediaElementMB = 50.0
-	var waveSurfer js.Value
-
-	sizeMB := bytesToMB(file.Get("size").Float())
-
-	if sizeMB > maxMediaElementMB {
-		backend := "MediaElement"
-		waveSurfer = waveSurferGlobal.Call("create", map[string]interface{}{
-			"container":     container,
-			"backend":       backend,
-			"partialRender": true,
-			"waveColor":     "#22c55e",
-			"progressColor": "#16a34a",
-			"cursorColor":   "#ffffff",
-			"barWidth":      2,
-			"barRadius":     1,
-			"responsive":    true,
-			"height":        120,
-			"normalize":     true,
-			"pixelRatio":    1,
-			"interact":      true,
-			"hideScrollbar": true,
-			"minPxPerSec":   maxPixelsPerSecond, // Limit resolution for large files
-		})
-	} else {
-		waveSurfer = waveSurferGlobal.Call("create", map[string]interface{}{
-			"container":     container,
-			"waveColor":     "#22c55e",
-			"progressColor": "#16a34a",
-			"cursorColor":   "#ffffff",
-			"barWidth":      2,
-			"barRadius":     1,
-			"responsive":    true,
-			"height":        120,
-			"normalize":     true,
-			"backend":       "WebAudio",
-			"pixelRatio":    1,
-			"interact":      true,
-			"hideScrollbar": true,
-			"minPxPerSec":   maxPixelsPerSecond, // Limit resolution for large files
-		})
+	maxPixelsPerSecond := defaultZoom
+
+	options := map[string]interface{}{
+		"container":     container,
+		"backend":       backend,
+		"waveColor":     "#22c55e",
+		"progressColor": "#16a34a",
+		"cursorColor":   "#ffffff",
+		"barWidth":      2,
+		"barRadius":     1,
+		"responsive":    true,
+		"height":        120,
+		"normalize":     true,
+		"pixelRatio":    1,
+		"interact":      true,
+		"hideScrollbar": true,
+		"minPxPerSec":   maxPixelsPerSecond, // Limit resolution for large files
 	}
+	if backend == "MediaElement" {
+		options["partialRender"] = true
+	}
+	waveSurfer := waveSurferGlobal.Call("create", options)
 
 	if waveSurfer.IsUndefined() {
 		log.Printf("❌ WAVESURFER CREATION FAILED: Could not create WaveSurfer instance")
@@ -1339,11 +5683,31 @@ func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
 	fileBlob := file
 	log.Printf("📁 FILE BLOB STORED: Ready for WaveSurfer loading")
 
+	failureHandled := false
+	retryOrFallback := func() {
+		if failureHandled {
+			return
+		}
+		failureHandled = true
+
+		if backend == "WebAudio" {
+			log.Printf("🔁 WEBAUDIO BACKEND FAILED: Retrying with MediaElement backend")
+			app.showToast("Audio is too large to decode in memory, retrying with streaming playback", "info")
+			waveSurfer.Call("destroy")
+			app.createWaveSurfer(file, fileName, "MediaElement")
+			return
+		}
+		log.Printf("❌ MEDIAELEMENT BACKEND FAILED: Creating fallback waveform")
+		app.createFallbackWaveform(container, fileName)
+		app.droppedFileLoaded(true)
+	}
+
 	log.Printf("🎧 SETTING UP WAVESURFER EVENT LISTENERS...")
 
 	waveSurfer.Call("on", "ready", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		duration := waveSurfer.Call("getDuration").Float()
-		log.Printf("✅ WAVESURFER READY: Waveform loaded, Duration=%.2fs", duration)
+		log.Printf("✅ WAVESURFER READY: Waveform loaded with %s backend, Duration=%.2fs", backend, duration)
+		app.hideLoadingProgress()
 
 		app.audioData = &AudioData{
 			FileName:   fileName,
@@ -1352,11 +5716,22 @@ func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
 			FileBlob:   fileBlob,
 		}
 
+		waveSurfer.Call("setVolume", app.volume)
+		if app.zoom != defaultZoom {
+			app.applyZoom()
+		}
 		app.updateAudioUI()
-		app.showToast(fmt.Sprintf("Loaded audio: %s (%.1fs)", fileName, duration), "success")
+		app.showToast(fmt.Sprintf("Loaded audio: %s (%s)", fileName, app.formatDuration(duration)), "success")
+		if !app.pairAudioWithTranscription(fileName) {
+			app.checkDurationMismatch()
+		}
+		app.checkAudioMatchesTranscript()
 
-		log.Printf("📝 SWITCHING TO VISUALIZATION VIEW")
-		app.showVisualizationView(js.Value{}, []js.Value{})
+		if !app.droppedFileLoaded(true) {
+			log.Printf("📝 SWITCHING TO VISUALIZATION VIEW")
+			app.showVisualizationView(js.Value{}, []js.Value{})
+		}
+		app.applyPendingStartTime()
 
 		return nil
 	}))
@@ -1368,6 +5743,7 @@ func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
 
 			progressMessage := fmt.Sprintf("Loading and analyzing audio: %.1f%% complete", progress)
 			app.updateLoadingMessage(progressMessage)
+			app.updateLoadingProgress(progress)
 		}
 		return nil
 	}))
@@ -1385,10 +5761,11 @@ func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
 			}
 		}
 		log.Printf("❌ WAVESURFER ERROR: %s", errorMsg)
+		app.hideLoadingProgress()
 
 		if strings.Contains(errorMsg, "Invalid array length") || strings.Contains(errorMsg, "RangeError") {
-			log.Printf("🔧 MEMORY ERROR DETECTED: Audio file too large for current settings")
-			app.showToast("Audio file is very large. Waveform display may be limited.", "warning")
+			log.Printf("🔧 MEMORY ERROR DETECTED: Audio file too large for the %s backend", backend)
+			retryOrFallback()
 		} else {
 			app.showToast("Failed to load audio: "+errorMsg, "error")
 			app.showUploadState()
@@ -1396,48 +5773,278 @@ func (app *AudioPipeApp) initializeWaveSurfer(file js.Value, fileName string) {
 		return nil
 	}))
 
-	waveSurfer.Call("on", "play", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		log.Printf("▶️ WAVESURFER PLAY: Audio playback started")
-		app.isPlaying = true
-		app.updatePlayButton()
+	waveSurfer.Call("on", "play", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		log.Printf("▶️ WAVESURFER PLAY: Audio playback started")
+		app.isPlaying = true
+		app.updatePlayButton()
+		return nil
+	}))
+
+	waveSurfer.Call("on", "pause", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		log.Printf("⏸️ WAVESURFER PAUSE: Audio playback paused")
+		app.isPlaying = false
+		app.updatePlayButton()
+		return nil
+	}))
+
+	waveSurfer.Call("on", "interaction", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) > 0 {
+			app.noteManualSeek(args[0].Float())
+		}
+		return nil
+	}))
+
+	waveSurfer.Call("on", "audioprocess", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) > 0 {
+			currentTime := args[0].Float()
+			app.currentTime = currentTime
+			if app.loopRange != nil && currentTime >= app.loopRange.End {
+				app.seekToTime(js.Value{}, []js.Value{js.ValueOf(app.loopRange.Start)})
+				return nil
+			}
+			if app.skipSilenceAt(currentTime) {
+				return nil
+			}
+			app.updateTimeDisplay()
+			app.highlightCurrentSpeaker()
+		}
+		return nil
+	}))
+
+	log.Printf("✅ WAVESURFER EVENT LISTENERS SETUP COMPLETE")
+
+	log.Printf("📂 LOADING AUDIO FILE INTO WAVESURFER...")
+	app.updateLoadingProgress(0)
+
+	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		promise := js.Global().Get("Promise").Call("resolve").Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			waveSurfer.Call("loadBlob", fileBlob)
+			return nil
+		}))
+
+		promise.Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			log.Printf("❌ WAVESURFER LOADBLOB FAILED: %s backend could not load the file", backend)
+			retryOrFallback()
+			return nil
+		}))
+
+		return nil
+	}), 100)
+}
+
+func (app *AudioPipeApp) getPeaks(maxLength int) (peaks []float64, ok bool) {
+	if app.audioData == nil || app.audioData.WaveSurfer.IsUndefined() {
+		return nil, false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("⚠️ PEAKS UNAVAILABLE: %v", r)
+			peaks, ok = nil, false
+		}
+	}()
+
+	waveSurfer := app.audioData.WaveSurfer
+	if waveSurfer.Get("exportPeaks").IsUndefined() {
+		return nil, false
+	}
+
+	channels := waveSurfer.Call("exportPeaks", map[string]interface{}{
+		"channels":  1,
+		"maxLength": maxLength,
+		"precision": 10000,
+	})
+	if channels.IsUndefined() || channels.IsNull() || channels.Length() == 0 {
+		return nil, false
+	}
+
+	channel := channels.Index(0)
+	peaks = make([]float64, channel.Length())
+	for i := range peaks {
+		peaks[i] = math.Abs(channel.Index(i).Float())
+	}
+
+	return peaks, len(peaks) > 0
+}
+
+func (app *AudioPipeApp) readStartTimeParam() {
+	search := js.Global().Get("location").Get("search").String()
+	if search == "" {
+		return
+	}
+
+	value := js.Global().Get("URLSearchParams").New(search).Call("get", "t")
+	if value.IsNull() {
+		return
+	}
+
+	if seconds, ok := parseStartParam(value.String()); ok {
+		app.pendingStartTime = seconds
+		log.Printf("🔗 START TIME FROM LINK: %.2fs", seconds)
+	} else {
+		log.Printf("⚠️ IGNORING INVALID START TIME PARAM: %q", value.String())
+	}
+}
+
+func (app *AudioPipeApp) applyPendingStartTime() {
+	if app.pendingStartTime < 0 || app.audioData == nil {
+		return
+	}
+
+	target := math.Min(app.pendingStartTime, app.audioData.Duration)
+	app.pendingStartTime = -1
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(target)})
+	app.showToast(fmt.Sprintf("Jumped to %s from link", formatTime(target)), "info")
+}
+
+func (app *AudioPipeApp) copyPositionLink(this js.Value, args []js.Value) interface{} {
+	if app.audioData == nil {
+		app.showToast("No audio file loaded", "warning")
+		return nil
+	}
+
+	seconds := int(math.Floor(app.currentTime))
+	link := js.Global().Get("URL").New(js.Global().Get("location").Get("href").String())
+	link.Get("searchParams").Call("set", "t", strconv.Itoa(seconds))
+	link.Set("hash", "")
+
+	app.copyToClipboard(link.Call("toString").String(),
+		fmt.Sprintf("Link to %s copied to clipboard", formatTime(float64(seconds))))
+	return nil
+}
+
+func (app *AudioPipeApp) seekToLoudest(this js.Value, args []js.Value) interface{} {
+	if app.audioData == nil {
+		app.showToast("No audio file loaded", "warning")
 		return nil
-	}))
+	}
 
-	waveSurfer.Call("on", "pause", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		log.Printf("⏸️ WAVESURFER PAUSE: Audio playback paused")
-		app.isPlaying = false
-		app.updatePlayButton()
+	peaks, ok := app.getPeaks(peakSampleCount)
+	if !ok || app.audioData.Duration <= 0 {
+		app.showToast("Waveform data is not available for this audio", "warning")
 		return nil
-	}))
+	}
 
-	waveSurfer.Call("on", "audioprocess", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		if len(args) > 0 {
-			currentTime := args[0].Float()
-			app.currentTime = currentTime
-			app.updateTimeDisplay()
-			app.highlightCurrentSpeaker()
+	window := int(float64(len(peaks)) * loudestWindowSeconds / app.audioData.Duration)
+	if window < 1 {
+		window = 1
+	}
+	if window > len(peaks) {
+		window = len(peaks)
+	}
+
+	sum := 0.0
+	for i := 0; i < window; i++ {
+		sum += peaks[i]
+	}
+
+	bestSum, bestStart := sum, 0
+	for i := window; i < len(peaks); i++ {
+		sum += peaks[i] - peaks[i-window]
+		if sum > bestSum {
+			bestSum, bestStart = sum, i-window+1
 		}
+	}
+
+	if bestSum <= 0 {
+		app.showToast("Audio appears to be silent", "warning")
 		return nil
-	}))
+	}
 
-	log.Printf("✅ WAVESURFER EVENT LISTENERS SETUP COMPLETE")
+	target := float64(bestStart) / float64(len(peaks)) * app.audioData.Duration
+	log.Printf("🔊 LOUDEST MOMENT: %.2fs (avg peak %.3f)", target, bestSum/float64(window))
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(target)})
+	app.showToast(fmt.Sprintf("Jumped to loudest moment at %s", formatTime(target)), "info")
+	return nil
+}
 
-	log.Printf("📂 LOADING AUDIO FILE INTO WAVESURFER...")
+func (app *AudioPipeApp) peakInRange(peaks []float64, start, end float64) float64 {
+	duration := app.audioData.Duration
+	if duration <= 0 || len(peaks) == 0 {
+		return 0
+	}
 
-	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		promise := js.Global().Get("Promise").Call("resolve").Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			waveSurfer.Call("loadBlob", fileBlob)
-			return nil
-		}))
+	first := int(start / duration * float64(len(peaks)))
+	last := int(end / duration * float64(len(peaks)))
+	if first < 0 {
+		first = 0
+	}
+	if last >= len(peaks) {
+		last = len(peaks) - 1
+	}
 
-		promise.Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			log.Printf("❌ WAVESURFER LOADBLOB FAILED: Creating fallback waveform")
-			app.createFallbackWaveform(container, fileName)
-			return nil
-		}))
+	maxPeak := 0.0
+	for i := first; i <= last; i++ {
+		if peaks[i] > maxPeak {
+			maxPeak = peaks[i]
+		}
+	}
+	return maxPeak
+}
 
-		return nil
-	}), 100)
+func (app *AudioPipeApp) checkDurationMismatch() {
+	if app.audioData == nil || app.transcriptionData == nil || app.audioData.Duration <= 0 {
+		return
+	}
+
+	audioDuration := app.audioData.Duration
+	transcriptDuration := app.statistics.TotalDuration
+	difference := math.Abs(audioDuration - transcriptDuration)
+	if difference < minDurationMismatch || difference < durationMismatchRatio*math.Max(audioDuration, transcriptDuration) {
+		return
+	}
+
+	log.Printf("⚠️ DURATION MISMATCH: audio %.2fs, transcript %.2fs", audioDuration, transcriptDuration)
+	app.showToast(fmt.Sprintf("Audio is %s but the transcript spans %s, enable SCALE TO AUDIO to align the tracks",
+		formatTime(audioDuration), formatTime(transcriptDuration)), "warning")
+}
+
+func (app *AudioPipeApp) visualizationDuration() float64 {
+	if app.scaleToAudio && app.audioData != nil && app.audioData.Duration > 0 {
+		return app.audioData.Duration
+	}
+	return app.statistics.TotalDuration
+}
+
+func (app *AudioPipeApp) checkAudioMatchesTranscript() {
+	if app.audioData == nil || app.transcriptionData == nil {
+		return
+	}
+
+	peaks, ok := app.getPeaks(peakSampleCount)
+	if !ok {
+		log.Printf("⚠️ SILENCE CHECK SKIPPED: Decoded peaks not available")
+		return
+	}
+
+	var candidates []Segment
+	for _, segment := range app.transcriptionData.Segments {
+		if segment.End > segment.Start && segment.Start < app.audioData.Duration {
+			candidates = append(candidates, segment)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	checks := maxSilenceCheckRanges
+	if len(candidates) < checks {
+		checks = len(candidates)
+	}
+
+	silentRanges := 0
+	for i := 0; i < checks; i++ {
+		segment := candidates[i*len(candidates)/checks]
+		if app.peakInRange(peaks, segment.Start, segment.End) < silentAmplitude {
+			silentRanges++
+		}
+	}
+
+	log.Printf("🔇 SILENCE CHECK: %d of %d sampled speech ranges are silent", silentRanges, checks)
+
+	if silentRanges == checks {
+		app.showToast("Audio is silent where the transcript has speech, this may be the wrong audio file", "warning")
+	}
 }
 
 func (app *AudioPipeApp) createFallbackWaveform(container js.Value, fileName string) {
@@ -1468,18 +6075,14 @@ func (app *AudioPipeApp) updateConsolidationThreshold(this js.Value, args []js.V
 	if len(args) > 0 {
 		valueStr := args[0].Get("target").Get("value").String()
 		threshold, err := strconv.ParseFloat(valueStr, 64)
-		if err != nil {
+		if err != nil || math.IsNaN(threshold) {
 			log.Printf("Error parsing threshold value: %v", err)
+			app.syncThresholdControls()
 			return nil
 		}
 
-		app.consolidationThreshold = threshold
-
-		document := js.Global().Get("document")
-		thresholdValue := document.Call("getElementById", "threshold-value")
-		if !thresholdValue.IsNull() {
-			thresholdValue.Set("textContent", fmt.Sprintf("%.1fs", threshold))
-		}
+		app.consolidationThreshold = math.Max(minConsolidationThreshold, math.Min(threshold, maxConsolidationThreshold))
+		app.syncThresholdControls()
 	}
 	return nil
 }
@@ -1492,56 +6095,157 @@ func (app *AudioPipeApp) applyConsolidation(this js.Value, args []js.Value) inte
 
 	app.showLoadingState("Consolidating segments...")
 
-	consolidated := app.consolidateSegmentsByThreshold(app.consolidationThreshold)
+	consolidated := app.consolidateSegmentsByThreshold(app.activeConsolidationThreshold(), app.consolidationMaxDuration, app.consolidationMinWords)
 	app.consolidatedData = consolidated
 	app.isConsolidated = true
+	app.saveSession()
 
-	app.showToast(fmt.Sprintf("Consolidated %d segments into %d groups", len(app.transcriptionData.Segments), len(consolidated)), "success")
+	mode := ""
+	if app.consolidateBySpeaker {
+		mode = " by speaker"
+	}
+	app.showToast(fmt.Sprintf("Consolidated %d segments into %d groups%s", len(app.transcriptionData.Segments), len(consolidated), mode), "success")
 
-	if app.currentView == "timeline" {
-		app.showTimelineView(js.Value{}, []js.Value{})
-	} else if app.currentView == "visualization" {
-		app.showVisualizationView(js.Value{}, []js.Value{})
+	app.refreshCurrentView()
+	return nil
+}
+
+func (app *AudioPipeApp) resetConsolidation(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil {
+		app.showToast("No transcription data loaded", "warning")
+		return nil
 	}
 
+	app.isConsolidated = false
+	app.consolidatedData = nil
+	app.consolidationThreshold = defaultConsolidationThreshold
+	app.consolidateBySpeaker = false
+	app.syncThresholdControls()
+	app.saveSession()
+
+	app.showToast(fmt.Sprintf("Restored %d original segments", len(app.transcriptionData.Segments)), "success")
+
+	app.refreshCurrentView()
 	return nil
 }
 
-func (app *AudioPipeApp) consolidateSegmentsByThreshold(threshold float64) []ConsolidatedSegment {
-	if app.transcriptionData == nil || len(app.transcriptionData.Segments) == 0 {
-		return []ConsolidatedSegment{}
+func (app *AudioPipeApp) syncThresholdControls() {
+	document := js.Global().Get("document")
+
+	thresholdSlider := document.Call("getElementById", "consolidation-threshold")
+	if !thresholdSlider.IsNull() {
+		thresholdSlider.Set("value", app.consolidationThreshold)
+		thresholdSlider.Set("disabled", app.consolidateBySpeaker)
 	}
 
-	segments := app.transcriptionData.Segments
-	var consolidated []ConsolidatedSegment
+	thresholdInput := document.Call("getElementById", "threshold-input")
+	if !thresholdInput.IsNull() {
+		thresholdInput.Set("value", strconv.FormatFloat(app.consolidationThreshold, 'f', -1, 64))
+		thresholdInput.Set("disabled", app.consolidateBySpeaker)
+	}
+
+	thresholdValue := document.Call("getElementById", "threshold-value")
+	if !thresholdValue.IsNull() {
+		thresholdValue.Set("textContent", thresholdLabel(app.activeConsolidationThreshold()))
+	}
 
-	currentGroup := ConsolidatedSegment{
-		Speaker: segments[0].Speaker,
-		Start:   segments[0].Start,
-		End:     segments[0].End,
-		Text:    segments[0].Text,
+	maxDurationInput := document.Call("getElementById", "consolidation-max-duration")
+	if !maxDurationInput.IsNull() {
+		maxDurationInput.Set("value", app.consolidationMaxDuration)
 	}
 
-	for i := 1; i < len(segments); i++ {
-		segment := segments[i]
-		gap := segment.Start - currentGroup.End
+	minWordsInput := document.Call("getElementById", "consolidation-min-words")
+	if !minWordsInput.IsNull() {
+		minWordsInput.Set("value", app.consolidationMinWords)
+	}
 
-		if segment.Speaker == currentGroup.Speaker && gap <= threshold {
-			currentGroup.End = segment.End
-			currentGroup.Text += " " + segment.Text
+	modeSelect := document.Call("getElementById", "consolidation-mode")
+	if !modeSelect.IsNull() {
+		if app.consolidateBySpeaker {
+			modeSelect.Set("value", "speaker")
 		} else {
-			consolidated = append(consolidated, currentGroup)
-			currentGroup = ConsolidatedSegment{
-				Speaker: segment.Speaker,
-				Start:   segment.Start,
-				End:     segment.End,
-				Text:    segment.Text,
-			}
+			modeSelect.Set("value", "gap")
+		}
+	}
+}
+
+func (app *AudioPipeApp) renderThresholdPreview() {
+	document := js.Global().Get("document")
+	container := document.Call("getElementById", "threshold-preview")
+	if container.IsNull() {
+		return
+	}
+
+	if app.transcriptionData == nil {
+		container.Set("innerHTML", "")
+		return
+	}
+
+	counts := make([]int, len(thresholdPreviewCandidates))
+	maxCount := 1
+	for i, threshold := range thresholdPreviewCandidates {
+		counts[i] = len(app.consolidateSegmentsByThreshold(threshold, app.consolidationMaxDuration, app.consolidationMinWords))
+		if counts[i] > maxCount {
+			maxCount = counts[i]
 		}
 	}
 
-	consolidated = append(consolidated, currentGroup)
-	return consolidated
+	var htmlBuilder strings.Builder
+	for i, threshold := range thresholdPreviewCandidates {
+		htmlBuilder.WriteString(fmt.Sprintf(`
+			<button class="threshold-option" data-threshold="%g" title="%d groups at a %gs gap threshold">
+				<span class="threshold-spark" style="height: %.0fpx"></span>
+				<span class="threshold-label">%gs</span>
+				<span class="threshold-count">%d</span>
+			</button>
+		`, threshold, counts[i], threshold, float64(counts[i])/float64(maxCount)*16, threshold, counts[i]))
+	}
+
+	container.Set("innerHTML", htmlBuilder.String())
+}
+
+func (app *AudioPipeApp) handleThresholdPreviewClick(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	option := args[0].Get("target").Call("closest", "[data-threshold]")
+	if option.IsNull() {
+		return nil
+	}
+
+	threshold, err := strconv.ParseFloat(option.Call("getAttribute", "data-threshold").String(), 64)
+	if err != nil {
+		log.Printf("Error parsing threshold option: %v", err)
+		return nil
+	}
+
+	app.consolidationThreshold = threshold
+	app.consolidateBySpeaker = false
+	app.syncThresholdControls()
+	return app.applyConsolidation(js.Value{}, []js.Value{})
+}
+
+func (app *AudioPipeApp) refreshCurrentView() {
+	if app.currentView == "timeline" {
+		app.showTimelineView(js.Value{}, []js.Value{})
+	} else if app.currentView == "visualization" {
+		app.showVisualizationView(js.Value{}, []js.Value{})
+	}
+}
+
+func (app *AudioPipeApp) activeConsolidationThreshold() float64 {
+	if app.consolidateBySpeaker {
+		return math.Inf(1)
+	}
+	return app.consolidationThreshold
+}
+
+func (app *AudioPipeApp) consolidateSegmentsByThreshold(threshold, maxDuration float64, minWords int) []ConsolidatedSegment {
+	if app.transcriptionData == nil {
+		return []ConsolidatedSegment{}
+	}
+	return consolidateByThreshold(app.transcriptionData.Segments, threshold, maxDuration, minWords)
 }
 
 func (app *AudioPipeApp) updateAudioUI() {
@@ -1558,7 +6262,7 @@ func (app *AudioPipeApp) updateAudioUI() {
 
 	totalTimeElement := document.Call("getElementById", "total-time")
 	if !totalTimeElement.IsNull() {
-		totalTimeElement.Set("textContent", app.formatTime(app.audioData.Duration))
+		totalTimeElement.Set("textContent", formatTime(app.audioData.Duration))
 	}
 }
 
@@ -1611,6 +6315,7 @@ func (app *AudioPipeApp) seekToTime(this js.Value, args []js.Value) interface{}
 	}
 
 	log.Printf("🎯 SEEKING TO TIME: %.2fs", targetTime)
+	app.noteManualSeek(targetTime)
 
 	waveSurfer := app.audioData.WaveSurfer
 	if !waveSurfer.IsUndefined() {
@@ -1626,9 +6331,196 @@ func (app *AudioPipeApp) seekToTime(this js.Value, args []js.Value) interface{}
 	return nil
 }
 
+func (app *AudioPipeApp) noteManualSeek(time float64) {
+	if gap, ok := gapAt(app.silenceGaps, time); ok {
+		app.silenceSkipExempt = &gap
+	} else {
+		app.silenceSkipExempt = nil
+	}
+}
+
+func (app *AudioPipeApp) skipSilenceAt(time float64) bool {
+	if !app.skipSilence || !app.isPlaying {
+		return false
+	}
+
+	gap, ok := gapAt(app.silenceGaps, time)
+	if !ok || (app.silenceSkipExempt != nil && *app.silenceSkipExempt == gap) {
+		return false
+	}
+
+	log.Printf("⏭️ SKIPPING SILENCE: %.2fs - %.2fs", gap.Start, gap.End)
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(gap.End)})
+	return true
+}
+
+func (app *AudioPipeApp) playFromTime(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || app.audioData == nil {
+		log.Printf("⚠️ PLAY FROM HERE IGNORED: No arguments or audio data")
+		return nil
+	}
+
+	app.seekToTime(js.Value{}, args)
+
+	waveSurfer := app.audioData.WaveSurfer
+	if !waveSurfer.IsUndefined() {
+		waveSurfer.Call("play")
+	}
+	return nil
+}
+
+func (app *AudioPipeApp) loopSegment(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return nil
+	}
+
+	start := args[0].Float()
+	end := args[1].Float()
+
+	if app.loopRange != nil && app.loopRange.Start == start && app.loopRange.End == end {
+		app.clearLoop()
+		app.showToast("Loop disengaged", "info")
+		return nil
+	}
+
+	if app.audioData == nil {
+		app.showToast("Load an audio file to loop a segment", "warning")
+		return nil
+	}
+	if end <= start {
+		app.showToast("Loop range is empty", "warning")
+		return nil
+	}
+
+	app.loopRange = &TimeRange{Start: start, End: end}
+	app.updateLoopIndicators()
+	app.playFromTime(js.Value{}, []js.Value{js.ValueOf(start)})
+	app.showToast(fmt.Sprintf("Looping %s - %s", formatTime(start), formatTime(end)), "info")
+	return nil
+}
+
+func (app *AudioPipeApp) clearLoop() {
+	app.loopRange = nil
+	app.updateLoopIndicators()
+}
+
+func (app *AudioPipeApp) loopClass(start, end float64) string {
+	if app.loopRange != nil &&
+		fmt.Sprintf("%.2f", app.loopRange.Start) == fmt.Sprintf("%.2f", start) &&
+		fmt.Sprintf("%.2f", app.loopRange.End) == fmt.Sprintf("%.2f", end) {
+		return " looping"
+	}
+	return ""
+}
+
+func (app *AudioPipeApp) updateLoopIndicators() {
+	rows := js.Global().Get("document").Call("querySelectorAll", ".timeline-segment-item")
+	for i := 0; i < rows.Length(); i++ {
+		row := rows.Index(i)
+		start, errS := strconv.ParseFloat(row.Call("getAttribute", "data-start").String(), 64)
+		end, errE := strconv.ParseFloat(row.Call("getAttribute", "data-end").String(), 64)
+		if errS == nil && errE == nil && app.loopClass(start, end) != "" {
+			row.Get("classList").Call("add", "looping")
+		} else {
+			row.Get("classList").Call("remove", "looping")
+		}
+	}
+}
+
+func (app *AudioPipeApp) sortedSegments() []Segment {
+	if app.transcriptionData == nil {
+		return []Segment{}
+	}
+	return sortSegmentsByStart(app.transcriptionData.Segments)
+}
+
+func (app *AudioPipeApp) currentSegmentIndex(segments []Segment) int {
+	current := -1
+	for i, segment := range segments {
+		if segment.Start > app.currentTime {
+			break
+		}
+		current = i
+	}
+	return current
+}
+
+func (app *AudioPipeApp) nextSpeakerTurn(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil || app.audioData == nil {
+		return nil
+	}
+
+	segments := app.sortedSegments()
+	current := app.currentSegmentIndex(segments)
+
+	currentSpeaker := ""
+	if current >= 0 {
+		currentSpeaker = segments[current].Speaker
+	}
+
+	for i := current + 1; i < len(segments); i++ {
+		if segments[i].Speaker != currentSpeaker && segments[i].Start > app.currentTime {
+			app.seekToTime(js.Value{}, []js.Value{js.ValueOf(segments[i].Start)})
+			return nil
+		}
+	}
+
+	app.showToast("No later speaker turn", "info")
+	return nil
+}
+
+func (app *AudioPipeApp) prevSpeakerTurn(this js.Value, args []js.Value) interface{} {
+	if app.transcriptionData == nil || app.audioData == nil {
+		return nil
+	}
+
+	segments := app.sortedSegments()
+	current := app.currentSegmentIndex(segments)
+	if current < 0 {
+		app.showToast("No earlier speaker turn", "info")
+		return nil
+	}
+
+	currentSpeaker := segments[current].Speaker
+	i := current
+	for i >= 0 && segments[i].Speaker == currentSpeaker {
+		i--
+	}
+	if i < 0 {
+		app.showToast("No earlier speaker turn", "info")
+		return nil
+	}
+
+	previousSpeaker := segments[i].Speaker
+	for i > 0 && segments[i-1].Speaker == previousSpeaker {
+		i--
+	}
+
+	app.seekToTime(js.Value{}, []js.Value{js.ValueOf(segments[i].Start)})
+	return nil
+}
+
+func (app *AudioPipeApp) activateSegmentElement(element js.Value) {
+	if app.audioData == nil {
+		return
+	}
+
+	start, err := strconv.ParseFloat(element.Call("getAttribute", "data-start").String(), 64)
+	if err != nil {
+		log.Printf("activateSegmentElement: invalid data-start: %v", err)
+		return
+	}
+
+	if app.clickToPlay {
+		app.playFromTime(js.Value{}, []js.Value{js.ValueOf(start)})
+	} else {
+		app.seekToTime(js.Value{}, []js.Value{js.ValueOf(start)})
+	}
+}
+
 func (app *AudioPipeApp) updatePlayButton() {
 	document := js.Global().Get("document")
-	playBtn := document.Call("querySelector", ".waveform-controls .waveform-btn i")
+	playBtn := document.Call("querySelector", "#play-toggle i")
 
 	if !playBtn.IsNull() {
 		if app.isPlaying {
@@ -1644,8 +6536,12 @@ func (app *AudioPipeApp) updateTimeDisplay() {
 	currentTimeElement := document.Call("getElementById", "current-time")
 
 	if !currentTimeElement.IsNull() {
-		currentTimeElement.Set("textContent", app.formatTime(app.currentTime))
+		currentTimeElement.Set("textContent", formatTime(app.currentTime))
 	}
+
+	app.updateTrackPlayheads()
 }
 
 func bytesToMB(b float64) float64 { return b / (1024 * 1024) }
+
+func htmlEscape(s string) string { return html.EscapeString(s) }
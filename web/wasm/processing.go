@@ -0,0 +1,838 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type TranscriptionData struct {
+	Language string                 `json:"language,omitempty"`
+	Model    string                 `json:"model,omitempty"`
+	Duration float64                `json:"duration,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Segments []Segment              `json:"segments"`
+}
+
+type Segment struct {
+	Speaker    string   `json:"speaker"`
+	Start      float64  `json:"start"`
+	End        float64  `json:"end"`
+	Text       string   `json:"text"`
+	Confidence *float64 `json:"confidence,omitempty"`
+	Words      []Word   `json:"words,omitempty"`
+	RawText    string   `json:"-"`
+}
+
+type Word struct {
+	Word  string   `json:"word"`
+	Start float64  `json:"start"`
+	End   float64  `json:"end"`
+	Score *float64 `json:"score,omitempty"`
+}
+
+type ConsolidatedSegment struct {
+	Speaker   string    `json:"speaker"`
+	Start     float64   `json:"start"`
+	End       float64   `json:"end"`
+	Text      string    `json:"text"`
+	Segments  []Segment `json:"segments"`
+	WordCount int       `json:"wordCount"`
+}
+
+type Statistics struct {
+	SegmentCount      int     `json:"segmentCount"`
+	SpeakerCount      int     `json:"speakerCount"`
+	TotalDuration     float64 `json:"totalDuration"`
+	WordCount         int     `json:"wordCount"`
+	WordsPerMinute    float64 `json:"wordsPerMinute"`
+	SilenceDuration   float64 `json:"silenceDuration"`
+	OverlapCount      int     `json:"overlapCount"`
+	TurnCount         int     `json:"turnCount"`
+	InterruptionCount int     `json:"interruptionCount"`
+}
+
+type TurnMetrics struct {
+	TurnCount         int
+	InterruptionCount int
+	SpeakerTurns      map[string]int
+	SpeakerTurnTime   map[string]float64
+}
+
+type Overlap struct {
+	Start    float64 `json:"start"`
+	End      float64 `json:"end"`
+	SpeakerA string  `json:"speakerA"`
+	SpeakerB string  `json:"speakerB"`
+}
+
+type Gap struct {
+	Start    float64 `json:"start"`
+	End      float64 `json:"end"`
+	Duration float64 `json:"duration"`
+}
+
+type SpeakerStat struct {
+	Speaker      string  `json:"speaker"`
+	Duration     float64 `json:"duration"`
+	SegmentCount int     `json:"segmentCount"`
+	WordCount    int     `json:"wordCount"`
+	Percentage   float64 `json:"percentage"`
+	TurnCount    int     `json:"turnCount"`
+	AverageTurn  float64 `json:"averageTurn"`
+}
+
+func sanitizeSegments(segments []Segment) ([]Segment, []string) {
+	valid := make([]Segment, 0, len(segments))
+	var problems []string
+
+	for i, segment := range segments {
+		switch {
+		case segment.Start < 0 || segment.End < 0:
+			problems = append(problems, fmt.Sprintf("segment %d has a negative time (%.2f - %.2f), skipped", i, segment.Start, segment.End))
+			continue
+		case strings.TrimSpace(segment.Text) == "":
+			problems = append(problems, fmt.Sprintf("segment %d at %.2fs has no text, skipped", i, segment.Start))
+			continue
+		case segment.End < segment.Start:
+			problems = append(problems, fmt.Sprintf("segment %d ends before it starts (%.2f - %.2f), swapped", i, segment.Start, segment.End))
+			segment.Start, segment.End = segment.End, segment.Start
+		}
+		valid = append(valid, segment)
+	}
+
+	return valid, problems
+}
+
+func uniqueSpeakers(segments []Segment) []string {
+	speakerMap := make(map[string]bool)
+	for _, segment := range segments {
+		speakerMap[segment.Speaker] = true
+	}
+
+	speakers := make([]string, 0, len(speakerMap))
+	for speaker := range speakerMap {
+		speakers = append(speakers, speaker)
+	}
+
+	sort.Strings(speakers)
+	return speakers
+}
+
+func activeWordIndex(words []Word, t float64) int {
+	i := sort.Search(len(words), func(i int) bool {
+		return words[i].Start > t
+	}) - 1
+	if i < 0 || t > words[i].End {
+		return -1
+	}
+	return i
+}
+
+// segmentIndexAt returns the last segment starting at or before t, or -1.
+// segments must be sorted by start time.
+func segmentIndexAt(segments []Segment, t float64) int {
+	return sort.Search(len(segments), func(i int) bool {
+		return segments[i].Start > t
+	}) - 1
+}
+
+// shortcutBinding returns the keys that trigger a shortcut bound to key and
+// the label shown for it in the help overlay. Letters match either case. ok is
+// false unless key is a single printable character.
+func shortcutBinding(key string) (keys []string, label string, ok bool) {
+	runes := []rune(key)
+	if len(runes) != 1 || !unicode.IsPrint(runes[0]) || unicode.IsSpace(runes[0]) {
+		return nil, "", false
+	}
+
+	lower, upper := strings.ToLower(key), strings.ToUpper(key)
+	if lower == upper {
+		return []string{key}, key, true
+	}
+	return []string{lower, upper}, upper, true
+}
+
+func fileStem(name string) string {
+	name = strings.ToLower(name)
+	if dot := strings.LastIndex(name, "."); dot > 0 {
+		name = name[:dot]
+	}
+	return name
+}
+
+// parseStartParam reads a "t" link parameter given either as seconds ("750")
+// or as a clock time ("12:30", "1:02:30").
+func parseStartParam(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	seconds := 0.0
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+			return 0, false
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, true
+}
+
+// speakerShown applies track mute/solo: when any speaker is soloed only soloed
+// speakers are shown, and muted speakers are always hidden.
+func speakerShown(speaker string, soloed, muted map[string]bool) bool {
+	if muted[speaker] {
+		return false
+	}
+	return len(soloed) == 0 || soloed[speaker]
+}
+
+const readingWordsPerMinute = 200.0
+
+func countWords(segments []Segment) int {
+	words := 0
+	for _, segment := range segments {
+		words += len(strings.Fields(segment.Text))
+	}
+	return words
+}
+
+func speakerLoadSummary(speaker string, segments []Segment) string {
+	words := countWords(segments)
+	minutes := float64(words) / readingWordsPerMinute
+	reading := fmt.Sprintf("~%.1f min", minutes)
+	if words > 0 && minutes < 0.1 {
+		reading = "<0.1 min"
+	}
+
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+	return fmt.Sprintf("%s: %d %s, %s reading time", speaker, words, unit, reading)
+}
+
+var smartQuoteReplacer = strings.NewReplacer(
+	"\u2018", "'", "\u2019", "'", "\u201A", "'", "\u201B", "'", "\u2032", "'",
+	"\u201C", `"`, "\u201D", `"`, "\u201E", `"`, "\u201F", `"`, "\u2033", `"`,
+)
+
+// normalizeText converts curly quotes to ASCII and collapses every run of
+// whitespace, including non-breaking spaces, into a single space.
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(smartQuoteReplacer.Replace(text)), " ")
+}
+
+// normalizeSegments normalizes segment text in place, keeping the original in
+// RawText so restoreRawText can undo it. It returns how many segments changed.
+func normalizeSegments(segments []Segment) int {
+	changed := 0
+	for i := range segments {
+		raw := segments[i].Text
+		if segments[i].RawText != "" {
+			raw = segments[i].RawText
+		}
+		if normalized := normalizeText(raw); normalized != raw {
+			segments[i].RawText = raw
+			segments[i].Text = normalized
+			changed++
+		}
+	}
+	return changed
+}
+
+func restoreRawText(segments []Segment) int {
+	restored := 0
+	for i := range segments {
+		if segments[i].RawText != "" {
+			segments[i].Text = segments[i].RawText
+			segments[i].RawText = ""
+			restored++
+		}
+	}
+	return restored
+}
+
+// collectRawText returns the raw text of normalized segments keyed by segment
+// index, so a saved session can keep it without putting it in JSON exports.
+func collectRawText(segments []Segment) map[int]string {
+	var raw map[int]string
+	for i, segment := range segments {
+		if segment.RawText == "" {
+			continue
+		}
+		if raw == nil {
+			raw = make(map[int]string)
+		}
+		raw[i] = segment.RawText
+	}
+	return raw
+}
+
+func applyRawText(segments []Segment, raw map[int]string) {
+	for i, text := range raw {
+		if i >= 0 && i < len(segments) {
+			segments[i].RawText = text
+		}
+	}
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// speakerFileNames returns one file name per speaker, safe for a ZIP entry.
+// Names that sanitize to nothing fall back to "speaker", and names that
+// collide (ignoring case) get a numeric suffix.
+func speakerFileNames(speakers []string) []string {
+	names := make([]string, len(speakers))
+	used := make(map[string]bool)
+	for i, speaker := range speakers {
+		base := unsafeFileNameChars.ReplaceAllString(speaker, "_")
+		if strings.Trim(base, "_.") == "" {
+			base = "speaker"
+		}
+
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+// pruneSpeakerColors removes colors for speakers that no longer have any
+// segments, leaving every other assignment untouched.
+func pruneSpeakerColors(colors map[string]string, segments []Segment) {
+	present := make(map[string]bool, len(colors))
+	for _, segment := range segments {
+		present[segment.Speaker] = true
+	}
+	for speaker := range colors {
+		if !present[speaker] {
+			delete(colors, speaker)
+		}
+	}
+}
+
+func matchesQuery(speaker, text, queryLower string) bool {
+	return strings.Contains(strings.ToLower(speaker+" "+text), queryLower)
+}
+
+func filterSegmentsByQuery(segments []Segment, query string) []Segment {
+	queryLower := strings.ToLower(strings.TrimSpace(query))
+	if queryLower == "" {
+		return segments
+	}
+
+	var matches []Segment
+	for _, segment := range segments {
+		if matchesQuery(segment.Speaker, segment.Text, queryLower) {
+			matches = append(matches, segment)
+		}
+	}
+	return matches
+}
+
+func segmentsInRange(segments []Segment, start, end float64) []Segment {
+	var inRange []Segment
+	for _, segment := range segments {
+		if segment.End > start && segment.Start < end {
+			inRange = append(inRange, segment)
+		}
+	}
+	return inRange
+}
+
+// transcriptionDecoder reads a transcription incrementally so a caller can
+// yield between batches of segments instead of blocking on one large decode.
+type transcriptionDecoder struct {
+	dec        *json.Decoder
+	data       TranscriptionData
+	bookmarks  json.RawMessage
+	started    bool
+	inSegments bool
+}
+
+func newTranscriptionDecoder(r io.Reader) *transcriptionDecoder {
+	return &transcriptionDecoder{dec: json.NewDecoder(r)}
+}
+
+// decode reads up to maxSegments segments and returns true once the whole
+// document has been read.
+func (d *transcriptionDecoder) decode(maxSegments int) (bool, error) {
+	if !d.started {
+		if err := d.expectDelim('{'); err != nil {
+			return false, err
+		}
+		d.started = true
+	}
+
+	decoded := 0
+	for {
+		if d.inSegments {
+			for d.dec.More() {
+				if decoded >= maxSegments {
+					return false, nil
+				}
+				var segment Segment
+				if err := d.dec.Decode(&segment); err != nil {
+					return false, err
+				}
+				d.data.Segments = append(d.data.Segments, segment)
+				decoded++
+			}
+			if err := d.expectDelim(']'); err != nil {
+				return false, err
+			}
+			d.inSegments = false
+			continue
+		}
+
+		if !d.dec.More() {
+			if err := d.expectDelim('}'); err != nil {
+				return false, err
+			}
+			if _, err := d.dec.Token(); err != io.EOF {
+				return false, errors.New("unexpected data after the transcription object")
+			}
+			return true, nil
+		}
+
+		token, err := d.dec.Token()
+		if err != nil {
+			return false, err
+		}
+		key, _ := token.(string)
+
+		switch {
+		case strings.EqualFold(key, "segments"):
+			token, err := d.dec.Token()
+			if err != nil {
+				return false, err
+			}
+			d.data.Segments = nil
+			if token == nil {
+				continue
+			}
+			if delim, ok := token.(json.Delim); !ok || delim != '[' {
+				return false, errors.New("segments must be an array")
+			}
+			d.inSegments = true
+		case strings.EqualFold(key, "language"):
+			err = d.dec.Decode(&d.data.Language)
+		case strings.EqualFold(key, "model"):
+			err = d.dec.Decode(&d.data.Model)
+		case strings.EqualFold(key, "duration"):
+			err = d.dec.Decode(&d.data.Duration)
+		case strings.EqualFold(key, "metadata"):
+			err = d.dec.Decode(&d.data.Metadata)
+		case strings.EqualFold(key, "bookmarks"):
+			err = d.dec.Decode(&d.bookmarks)
+		default:
+			var skipped json.RawMessage
+			err = d.dec.Decode(&skipped)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+func (d *transcriptionDecoder) expectDelim(want json.Delim) error {
+	token, err := d.dec.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, found %v", want, token)
+	}
+	return nil
+}
+
+func (d *transcriptionDecoder) offset() int64 {
+	return d.dec.InputOffset()
+}
+
+// orderSegments sorts segments in place by Start, then End, keeping input
+// order for identical timings.
+func orderSegments(segments []Segment) {
+	sort.SliceStable(segments, func(i, j int) bool {
+		if segments[i].Start != segments[j].Start {
+			return segments[i].Start < segments[j].Start
+		}
+		return segments[i].End < segments[j].End
+	})
+}
+
+// reorderSegments sorts segments in place in the same order as orderSegments
+// and returns selected remapped to the new indexes.
+func reorderSegments(segments []Segment, selected map[int]bool) map[int]bool {
+	order := make([]int, len(segments))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := segments[order[i]], segments[order[j]]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.End < b.End
+	})
+
+	sorted := make([]Segment, len(segments))
+	remapped := make(map[int]bool, len(selected))
+	for to, from := range order {
+		sorted[to] = segments[from]
+		if selected[from] {
+			remapped[to] = true
+		}
+	}
+	copy(segments, sorted)
+	return remapped
+}
+
+func sortSegmentsByStart(segments []Segment) []Segment {
+	sorted := make([]Segment, len(segments))
+	copy(sorted, segments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+	return sorted
+}
+
+func thresholdLabel(threshold float64) string {
+	if math.IsInf(threshold, 1) {
+		return "merge any gap"
+	}
+	return fmt.Sprintf("merge gaps ≤ %ss", strconv.FormatFloat(threshold, 'f', -1, 64))
+}
+
+// consolidateByThreshold merges consecutive same-speaker segments whose gap is
+// at most threshold. Input order does not matter; segments are merged in start
+// order.
+func consolidateByThreshold(segments []Segment, threshold, maxDuration float64, minWords int) []ConsolidatedSegment {
+	if len(segments) == 0 {
+		return []ConsolidatedSegment{}
+	}
+	segments = sortSegmentsByStart(segments)
+
+	var consolidated []ConsolidatedSegment
+
+	currentGroup := ConsolidatedSegment{
+		Speaker:   segments[0].Speaker,
+		Start:     segments[0].Start,
+		End:       segments[0].End,
+		Text:      segments[0].Text,
+		Segments:  []Segment{segments[0]},
+		WordCount: len(strings.Fields(segments[0].Text)),
+	}
+
+	for i := 1; i < len(segments); i++ {
+		segment := segments[i]
+		words := len(strings.Fields(segment.Text))
+		gap := segment.Start - currentGroup.End
+		withinCap := maxDuration <= 0 || segment.End-currentGroup.Start <= maxDuration
+		tinyFragment := minWords > 0 && (words < minWords || currentGroup.WordCount < minWords)
+
+		if segment.Speaker == currentGroup.Speaker && withinCap && (gap <= threshold || tinyFragment) {
+			currentGroup.End = segment.End
+			currentGroup.Text += " " + segment.Text
+			currentGroup.Segments = append(currentGroup.Segments, segment)
+			currentGroup.WordCount += words
+		} else {
+			consolidated = append(consolidated, currentGroup)
+			currentGroup = ConsolidatedSegment{
+				Speaker:   segment.Speaker,
+				Start:     segment.Start,
+				End:       segment.End,
+				Text:      segment.Text,
+				Segments:  []Segment{segment},
+				WordCount: words,
+			}
+		}
+	}
+
+	consolidated = append(consolidated, currentGroup)
+	return consolidated
+}
+
+// computeStatistics expects segments in any order; silence, overlap and turn
+// metrics are computed on a start-sorted copy.
+func computeStatistics(segments []Segment, silenceThreshold float64) Statistics {
+	speakerMap := make(map[string]bool)
+	totalWords := 0
+	maxEnd := 0.0
+	speakingDuration := 0.0
+
+	for _, segment := range segments {
+		speakerMap[segment.Speaker] = true
+		totalWords += len(strings.Fields(segment.Text))
+		speakingDuration += segment.End - segment.Start
+
+		if segment.End > maxEnd {
+			maxEnd = segment.End
+		}
+	}
+
+	wordsPerMinute := 0.0
+	if speakingDuration > 0 {
+		wordsPerMinute = float64(totalWords) / (speakingDuration / 60)
+	}
+
+	sorted := sortSegmentsByStart(segments)
+
+	silenceDuration := 0.0
+	for _, gap := range findSilences(sorted, silenceThreshold) {
+		silenceDuration += gap.Duration
+	}
+
+	turns := computeTurnMetrics(sorted)
+
+	return Statistics{
+		SegmentCount:      len(segments),
+		SpeakerCount:      len(speakerMap),
+		TotalDuration:     maxEnd,
+		WordCount:         totalWords,
+		WordsPerMinute:    wordsPerMinute,
+		SilenceDuration:   silenceDuration,
+		OverlapCount:      len(findOverlaps(sorted)),
+		TurnCount:         turns.TurnCount,
+		InterruptionCount: turns.InterruptionCount,
+	}
+}
+
+func findOverlaps(sorted []Segment) []Overlap {
+	var overlaps []Overlap
+	for i, a := range sorted {
+		for j := i + 1; j < len(sorted) && sorted[j].Start < a.End; j++ {
+			b := sorted[j]
+			if b.Speaker == a.Speaker {
+				continue
+			}
+			overlaps = append(overlaps, Overlap{
+				Start:    b.Start,
+				End:      math.Min(a.End, b.End),
+				SpeakerA: a.Speaker,
+				SpeakerB: b.Speaker,
+			})
+		}
+	}
+	return overlaps
+}
+
+func findSilences(sorted []Segment, threshold float64) []Gap {
+	var gaps []Gap
+	coveredUntil := 0.0
+	for _, segment := range sorted {
+		if segment.Start-coveredUntil > threshold {
+			gaps = append(gaps, Gap{
+				Start:    coveredUntil,
+				End:      segment.Start,
+				Duration: segment.Start - coveredUntil,
+			})
+		}
+		if segment.End > coveredUntil {
+			coveredUntil = segment.End
+		}
+	}
+	return gaps
+}
+
+// gapAt returns the gap containing t, given gaps sorted by Start.
+func gapAt(gaps []Gap, t float64) (Gap, bool) {
+	i := sort.Search(len(gaps), func(i int) bool { return gaps[i].End > t })
+	if i < len(gaps) && gaps[i].Start <= t {
+		return gaps[i], true
+	}
+	return Gap{}, false
+}
+
+const neutralConfidenceColor = "#9ca3af"
+
+// confidenceColor maps a confidence score onto a red (0) to green (1) hue;
+// segments without a score get a neutral gray.
+func confidenceColor(confidence *float64) string {
+	if confidence == nil || math.IsNaN(*confidence) {
+		return neutralConfidenceColor
+	}
+	value := math.Max(0, math.Min(*confidence, 1))
+	return fmt.Sprintf("hsl(%.0f, 70%%, 45%%)", value*120)
+}
+
+// meanConfidence averages the scored segments, or returns nil if none have a
+// score.
+func meanConfidence(segments []Segment) *float64 {
+	total, scored := 0.0, 0
+	for _, segment := range segments {
+		if segment.Confidence != nil {
+			total += *segment.Confidence
+			scored++
+		}
+	}
+	if scored == 0 {
+		return nil
+	}
+	mean := total / float64(scored)
+	return &mean
+}
+
+func computeTurnMetrics(sorted []Segment) TurnMetrics {
+	metrics := TurnMetrics{
+		SpeakerTurns:    make(map[string]int),
+		SpeakerTurnTime: make(map[string]float64),
+	}
+
+	for i := 0; i < len(sorted); {
+		turnStart, turnEnd := sorted[i].Start, sorted[i].End
+		j := i + 1
+		for j < len(sorted) && sorted[j].Speaker == sorted[i].Speaker {
+			turnEnd = math.Max(turnEnd, sorted[j].End)
+			j++
+		}
+
+		speaker := sorted[i].Speaker
+		metrics.TurnCount++
+		metrics.SpeakerTurns[speaker]++
+		metrics.SpeakerTurnTime[speaker] += turnEnd - turnStart
+
+		if j < len(sorted) && sorted[j].Start < sorted[j-1].End {
+			metrics.InterruptionCount++
+		}
+		i = j
+	}
+
+	return metrics
+}
+
+func formatTime(seconds float64) string {
+	totalSecs := int(seconds)
+	hours := totalSecs / 3600
+	mins := (totalSecs % 3600) / 60
+	secs := totalSecs % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, mins, secs)
+	}
+	return fmt.Sprintf("%d:%02d", mins, secs)
+}
+
+func formatTimePrecise(seconds float64) string {
+	totalMs := int(math.Round(seconds * 1000))
+	hours := totalMs / 3600000
+	mins := (totalMs % 3600000) / 60000
+	secs := (totalMs % 60000) / 1000
+	ms := totalMs % 1000
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d.%03d", hours, mins, secs, ms)
+	}
+	return fmt.Sprintf("%d:%02d.%03d", mins, secs, ms)
+}
+
+func formatSRTTime(seconds float64) string {
+	totalMs := int(math.Round(seconds * 1000))
+	hours := totalMs / 3600000
+	minutes := (totalMs % 3600000) / 60000
+	secs := (totalMs % 60000) / 1000
+	ms := totalMs % 1000
+
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", hours, minutes, secs, ms)
+}
+
+func formatVTTTime(seconds float64) string {
+	return strings.Replace(formatSRTTime(seconds), ",", ".", 1)
+}
+
+func buildTextExport(segments []Segment) string {
+	var textBuilder strings.Builder
+
+	for _, segment := range segments {
+		textBuilder.WriteString(fmt.Sprintf("[%s - %s] %s: %s\n\n",
+			formatTime(segment.Start), formatTime(segment.End),
+			segment.Speaker, segment.Text))
+	}
+
+	return textBuilder.String()
+}
+
+// applyTimeOffset returns a copy of segments shifted by offset seconds. Times
+// that would fall before zero are clamped to 0, and segments that end at or
+// before zero are dropped.
+func applyTimeOffset(segments []Segment, offset float64) []Segment {
+	shifted := make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		segment.Start = math.Max(0, segment.Start+offset)
+		segment.End = math.Max(0, segment.End+offset)
+		if segment.End <= 0 {
+			continue
+		}
+		shifted = append(shifted, segment)
+	}
+	return shifted
+}
+
+func buildSRTExport(segments []Segment) string {
+	var srtBuilder strings.Builder
+
+	for i, segment := range segments {
+		srtBuilder.WriteString(fmt.Sprintf("%d\n%s --> %s\n%s: %s\n\n",
+			i+1, formatSRTTime(segment.Start), formatSRTTime(segment.End),
+			segment.Speaker, segment.Text))
+	}
+
+	return srtBuilder.String()
+}
+
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func buildVTTExport(segments []Segment) string {
+	var vttBuilder strings.Builder
+	vttBuilder.WriteString("WEBVTT\n\n")
+
+	for i, segment := range segments {
+		text := strings.ReplaceAll(segment.Text, "-->", "->")
+		vttBuilder.WriteString(fmt.Sprintf("%d\n%s --> %s\n<v %s>%s\n\n",
+			i+1, formatVTTTime(segment.Start), formatVTTTime(segment.End),
+			vttEscaper.Replace(segment.Speaker), vttEscaper.Replace(text)))
+	}
+
+	return vttBuilder.String()
+}
+
+// viewSegments returns the segments an export of the current view should use:
+// the raw segments, or the consolidated blocks when consolidation is on. It
+// reports fellBack when consolidation is on but there are no blocks, in which
+// case the raw segments are returned.
+func viewSegments(segments []Segment, blocks []ConsolidatedSegment, consolidated bool) (view []Segment, fellBack bool) {
+	if !consolidated {
+		return segments, false
+	}
+	if len(blocks) == 0 {
+		return segments, true
+	}
+	return blocksAsSegments(blocks), false
+}
+
+func blocksAsSegments(blocks []ConsolidatedSegment) []Segment {
+	segments := make([]Segment, len(blocks))
+	for i, block := range blocks {
+		segments[i] = Segment{
+			Speaker: block.Speaker,
+			Start:   block.Start,
+			End:     block.End,
+			Text:    block.Text,
+		}
+	}
+	return segments
+}
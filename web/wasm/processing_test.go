@@ -0,0 +1,882 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "0:00"},
+		{59.9, "0:59"},
+		{61, "1:01"},
+		{3599, "59:59"},
+		{3600, "1:00:00"},
+		{3725.4, "1:02:05"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTime(tt.seconds); got != tt.want {
+			t.Errorf("formatTime(%v) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestFormatSRTTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "00:00:00,000"},
+		{1.5, "00:00:01,500"},
+		{2.3, "00:00:02,300"},
+		{59.9999, "00:01:00,000"},
+		{3661.25, "01:01:01,250"},
+	}
+
+	for _, tt := range tests {
+		if got := formatSRTTime(tt.seconds); got != tt.want {
+			t.Errorf("formatSRTTime(%v) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+
+	if got, want := formatVTTTime(3661.25), "01:01:01.250"; got != want {
+		t.Errorf("formatVTTTime(3661.25) = %q, want %q", got, want)
+	}
+}
+
+func TestBuildVTTExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		segment Segment
+		want    string
+	}{
+		{"plain", Segment{Speaker: "A", Start: 0, End: 1.5, Text: "hello"},
+			"WEBVTT\n\n1\n00:00:00.000 --> 00:00:01.500\n<v A>hello\n\n"},
+		{"markup in text", Segment{Speaker: "A", Start: 0, End: 1, Text: "1 < 2 & 3 > 2"},
+			"WEBVTT\n\n1\n00:00:00.000 --> 00:00:01.000\n<v A>1 &lt; 2 &amp; 3 &gt; 2\n\n"},
+		{"markup in speaker", Segment{Speaker: "<Host> & Co", Start: 0, End: 1, Text: "hi"},
+			"WEBVTT\n\n1\n00:00:00.000 --> 00:00:01.000\n<v &lt;Host&gt; &amp; Co>hi\n\n"},
+		{"cue arrow in text", Segment{Speaker: "A", Start: 0, End: 1, Text: "then --> now"},
+			"WEBVTT\n\n1\n00:00:00.000 --> 00:00:01.000\n<v A>then -&gt; now\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildVTTExport([]Segment{tt.segment}); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := buildVTTExport(nil); got != "WEBVTT\n\n" {
+		t.Errorf("empty export = %q", got)
+	}
+}
+
+func TestConsolidateByThreshold(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 1, Text: "one two"},
+		{Speaker: "A", Start: 1.5, End: 2, Text: "three"},
+		{Speaker: "B", Start: 2, End: 3, Text: "four"},
+		{Speaker: "A", Start: 3, End: 4, Text: "five"},
+	}
+	merged := ConsolidatedSegment{Speaker: "A", Start: 0, End: 2, Text: "one two three", Segments: segments[0:2], WordCount: 3}
+	first := ConsolidatedSegment{Speaker: "A", Start: 0, End: 1, Text: "one two", Segments: segments[0:1], WordCount: 2}
+	second := ConsolidatedSegment{Speaker: "A", Start: 1.5, End: 2, Text: "three", Segments: segments[1:2], WordCount: 1}
+	b := ConsolidatedSegment{Speaker: "B", Start: 2, End: 3, Text: "four", Segments: segments[2:3], WordCount: 1}
+	last := ConsolidatedSegment{Speaker: "A", Start: 3, End: 4, Text: "five", Segments: segments[3:4], WordCount: 1}
+
+	tests := []struct {
+		name        string
+		threshold   float64
+		maxDuration float64
+		minWords    int
+		want        []ConsolidatedSegment
+	}{
+		{"gap within threshold", 1, 0, 0, []ConsolidatedSegment{merged, b, last}},
+		{"gap equal to threshold", 0.5, 0, 0, []ConsolidatedSegment{merged, b, last}},
+		{"gap above threshold", 0.2, 0, 0, []ConsolidatedSegment{first, second, b, last}},
+		{"speaker change always splits", 100, 0, 0, []ConsolidatedSegment{merged, b, last}},
+		{"max duration exceeded", 1, 1.5, 0, []ConsolidatedSegment{first, second, b, last}},
+		{"max duration reached", 1, 2, 0, []ConsolidatedSegment{merged, b, last}},
+		{"tiny fragment absorbed", 0.2, 0, 2, []ConsolidatedSegment{merged, b, last}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := consolidateByThreshold(segments, tt.threshold, tt.maxDuration, tt.minWords)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	spread := []Segment{
+		{Speaker: "A", Start: 0, End: 1, Text: "one"},
+		{Speaker: "A", Start: 120, End: 121, Text: "two"},
+		{Speaker: "B", Start: 300, End: 301, Text: "three"},
+	}
+	bySpeaker := consolidateByThreshold(spread, math.Inf(1), 0, 0)
+	wantBySpeaker := []ConsolidatedSegment{
+		{Speaker: "A", Start: 0, End: 121, Text: "one two", Segments: spread[0:2], WordCount: 2},
+		{Speaker: "B", Start: 300, End: 301, Text: "three", Segments: spread[2:3], WordCount: 1},
+	}
+	if !reflect.DeepEqual(bySpeaker, wantBySpeaker) {
+		t.Errorf("speaker-only consolidation = %+v, want %+v", bySpeaker, wantBySpeaker)
+	}
+
+	grouped := []Segment{segments[0], segments[1], segments[3], segments[2]}
+	if got := consolidateByThreshold(grouped, 1, 0, 0); !reflect.DeepEqual(got, []ConsolidatedSegment{merged, b, last}) {
+		t.Errorf("consolidating speaker-grouped input = %+v, want %+v", got, []ConsolidatedSegment{merged, b, last})
+	}
+
+	if got := consolidateByThreshold(nil, 1, 0, 0); len(got) != 0 {
+		t.Errorf("consolidating no segments returned %d blocks", len(got))
+	}
+}
+
+func TestTranscriptionDecoder(t *testing.T) {
+	input := `{
+		"language": "en",
+		"model": "large-v3",
+		"duration": 12.5,
+		"metadata": {"source": "test"},
+		"extra": [1, {"nested": true}],
+		"segments": [
+			{"speaker": "A", "start": 0, "end": 1, "text": "one"},
+			{"speaker": "B", "start": 1, "end": 2, "text": "two", "confidence": 0.5},
+			{"speaker": "A", "start": 2, "end": 3, "text": "three", "words": [{"word": "three", "start": 2, "end": 3}]}
+		],
+		"bookmarks": [{"time": 1, "label": "x"}]
+	}`
+
+	var want TranscriptionData
+	if err := json.Unmarshal([]byte(input), &want); err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := newTranscriptionDecoder(strings.NewReader(input))
+	steps := 0
+	for {
+		done, err := decoder.decode(1)
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		steps++
+		if done {
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(decoder.data, want) {
+		t.Errorf("decoded %+v, want %+v", decoder.data, want)
+	}
+	if steps < 3 {
+		t.Errorf("decoded in %d steps, want at least one per segment", steps)
+	}
+	if string(decoder.bookmarks) != `[{"time": 1, "label": "x"}]` {
+		t.Errorf("bookmarks = %s", decoder.bookmarks)
+	}
+
+	for _, bad := range []string{``, `[]`, `{"segments": {}}`, `{"segments": [{"speaker": "A"`, `{"segments": []} {}`, `{"duration": "long"}`} {
+		decoder := newTranscriptionDecoder(strings.NewReader(bad))
+		var err error
+		for done := false; !done && err == nil; {
+			done, err = decoder.decode(100)
+		}
+		if err == nil {
+			t.Errorf("decoding %q succeeded, want an error", bad)
+		}
+	}
+
+	decoder = newTranscriptionDecoder(strings.NewReader(`{"segments": null}`))
+	if done, err := decoder.decode(100); !done || err != nil || decoder.data.Segments != nil {
+		t.Errorf("null segments: done=%v err=%v segments=%v", done, err, decoder.data.Segments)
+	}
+}
+
+func TestOrderSegments(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "B", Start: 4, End: 6, Text: "b1"},
+		{Speaker: "B", Start: 1, End: 3, Text: "b0"},
+		{Speaker: "A", Start: 1, End: 2, Text: "a0"},
+		{Speaker: "A", Start: 4, End: 6, Text: "a1"},
+		{Speaker: "A", Start: 0, End: 1, Text: "a-first"},
+	}
+	orderSegments(segments)
+
+	want := []string{"a-first", "a0", "b0", "b1", "a1"}
+	for i, segment := range segments {
+		if segment.Text != want[i] {
+			t.Fatalf("order = %v, want %v", segmentTexts(segments), want)
+		}
+	}
+}
+
+func segmentTexts(segments []Segment) []string {
+	texts := make([]string, len(segments))
+	for i, segment := range segments {
+		texts[i] = segment.Text
+	}
+	return texts
+}
+
+func TestParseStartParam(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   float64
+		wantOK bool
+	}{
+		{"750", 750, true},
+		{"12.5", 12.5, true},
+		{"12:30", 750, true},
+		{"1:02:30", 3750, true},
+		{" 90 ", 90, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"abc", 0, false},
+		{"12:", 0, false},
+		{"Inf", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseStartParam(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseStartParam(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestThresholdLabel(t *testing.T) {
+	tests := map[float64]string{
+		0:           "merge gaps ≤ 0s",
+		2.5:         "merge gaps ≤ 2.5s",
+		10:          "merge gaps ≤ 10s",
+		1.25:        "merge gaps ≤ 1.25s",
+		math.Inf(1): "merge any gap",
+	}
+
+	for threshold, want := range tests {
+		if got := thresholdLabel(threshold); got != want {
+			t.Errorf("thresholdLabel(%v) = %q, want %q", threshold, got, want)
+		}
+	}
+}
+
+func TestGapAt(t *testing.T) {
+	gaps := []Gap{{Start: 2, End: 5, Duration: 3}, {Start: 8, End: 12, Duration: 4}}
+
+	tests := []struct {
+		time   float64
+		want   Gap
+		wantOK bool
+	}{
+		{0, Gap{}, false},
+		{2, gaps[0], true},
+		{4.9, gaps[0], true},
+		{5, Gap{}, false},
+		{10, gaps[1], true},
+		{12, Gap{}, false},
+		{20, Gap{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := gapAt(gaps, tt.time)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("gapAt(%v) = %+v, %v; want %+v, %v", tt.time, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if _, ok := gapAt(nil, 1); ok {
+		t.Error("gapAt on no gaps reported a gap")
+	}
+}
+
+func TestSpeakerShown(t *testing.T) {
+	tests := []struct {
+		name    string
+		speaker string
+		soloed  map[string]bool
+		muted   map[string]bool
+		want    bool
+	}{
+		{"no mute or solo", "A", nil, nil, true},
+		{"muted", "A", nil, map[string]bool{"A": true}, false},
+		{"other speaker muted", "B", nil, map[string]bool{"A": true}, true},
+		{"soloed", "A", map[string]bool{"A": true}, nil, true},
+		{"another speaker soloed", "B", map[string]bool{"A": true}, nil, false},
+		{"muted wins over solo", "A", map[string]bool{"A": true}, map[string]bool{"A": true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := speakerShown(tt.speaker, tt.soloed, tt.muted); got != tt.want {
+				t.Errorf("speakerShown(%q) = %v, want %v", tt.speaker, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTimeOffset(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 1.5, Text: "intro"},
+		{Speaker: "B", Start: 2, End: 4, Text: "hello"},
+		{Speaker: "A", Start: 5, End: 6, Text: "bye"},
+	}
+
+	tests := []struct {
+		name   string
+		offset float64
+		want   []Segment
+	}{
+		{"no offset", 0, segments},
+		{"positive offset", 2.5, []Segment{
+			{Speaker: "A", Start: 2.5, End: 4, Text: "intro"},
+			{Speaker: "B", Start: 4.5, End: 6.5, Text: "hello"},
+			{Speaker: "A", Start: 7.5, End: 8.5, Text: "bye"},
+		}},
+		{"negative offset clamps and drops", -3, []Segment{
+			{Speaker: "B", Start: 0, End: 1, Text: "hello"},
+			{Speaker: "A", Start: 2, End: 3, Text: "bye"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyTimeOffset(segments, tt.offset); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if segments[1].Start != 2 {
+		t.Error("applyTimeOffset modified its input")
+	}
+}
+
+func TestSpeakerLoadSummary(t *testing.T) {
+	many := make([]Segment, 3)
+	for i := range many {
+		many[i] = Segment{Speaker: "A", Text: strings.Repeat("word ", 100)}
+	}
+
+	tests := []struct {
+		name     string
+		segments []Segment
+		want     string
+	}{
+		{"no segments", nil, "A: 0 words, ~0.0 min reading time"},
+		{"single word", []Segment{{Text: "hi"}}, "A: 1 word, <0.1 min reading time"},
+		{"several segments", many, "A: 300 words, ~1.5 min reading time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := speakerLoadSummary("A", tt.segments); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := map[string]string{
+		"plain text":                       "plain text",
+		"  padded  ":                       "padded",
+		"double  space\tand\nnewline":      "double space and newline",
+		"non\u00a0breaking\u202fspace":     "non breaking space",
+		"\u201cquoted\u201d and it\u2019s": `"quoted" and it's`,
+		"\u2018single\u2019":               "'single'",
+		"":                                 "",
+	}
+
+	for input, want := range tests {
+		if got := normalizeText(input); got != want {
+			t.Errorf("normalizeText(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeAndRestoreSegments(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Text: "it\u2019s  fine "},
+		{Speaker: "B", Text: "already clean"},
+	}
+
+	if changed := normalizeSegments(segments); changed != 1 {
+		t.Fatalf("normalizeSegments changed %d segments, want 1", changed)
+	}
+	if segments[0].Text != "it's fine" || segments[0].RawText != "it\u2019s  fine " {
+		t.Errorf("normalized segment = %+v", segments[0])
+	}
+	if segments[1].RawText != "" {
+		t.Errorf("unchanged segment kept raw text %q", segments[1].RawText)
+	}
+
+	if changed := normalizeSegments(segments); changed != 1 || segments[0].RawText != "it\u2019s  fine " {
+		t.Errorf("normalizing twice lost the raw text: %+v", segments[0])
+	}
+
+	if restored := restoreRawText(segments); restored != 1 {
+		t.Fatalf("restoreRawText restored %d segments, want 1", restored)
+	}
+	if segments[0].Text != "it\u2019s  fine " || segments[0].RawText != "" {
+		t.Errorf("restored segment = %+v", segments[0])
+	}
+}
+
+func TestRawTextSessionRoundTrip(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Text: "clean"},
+		{Speaker: "B", Text: "\u201Cquoted\u201D  text"},
+	}
+	normalizeSegments(segments)
+
+	exported, err := json.Marshal(segments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(exported), "quoted\u201D") {
+		t.Errorf("export leaked raw text: %s", exported)
+	}
+
+	saved, err := json.Marshal(collectRawText(segments))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[int]string
+	if err := json.Unmarshal(saved, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	var restored []Segment
+	if err := json.Unmarshal(exported, &restored); err != nil {
+		t.Fatal(err)
+	}
+	applyRawText(restored, raw)
+	if !reflect.DeepEqual(restored, segments) {
+		t.Fatalf("round trip = %+v, want %+v", restored, segments)
+	}
+
+	restoreRawText(restored)
+	if restored[1].Text != "\u201Cquoted\u201D  text" {
+		t.Errorf("restored text = %q after session round trip", restored[1].Text)
+	}
+
+	applyRawText(restored, map[int]string{-1: "x", 5: "y"})
+	if collectRawText(restored) != nil {
+		t.Errorf("out-of-range raw text was applied")
+	}
+}
+
+func TestConfidenceColor(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		confidence *float64
+		want       string
+	}{
+		{"missing", nil, neutralConfidenceColor},
+		{"zero is red", score(0), "hsl(0, 70%, 45%)"},
+		{"half is yellow", score(0.5), "hsl(60, 70%, 45%)"},
+		{"one is green", score(1), "hsl(120, 70%, 45%)"},
+		{"clamped below", score(-0.3), "hsl(0, 70%, 45%)"},
+		{"clamped above", score(1.7), "hsl(120, 70%, 45%)"},
+		{"not a number", score(math.NaN()), neutralConfidenceColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := confidenceColor(tt.confidence); got != tt.want {
+				t.Errorf("confidenceColor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeanConfidence(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	if got := meanConfidence([]Segment{{Text: "a"}, {Text: "b"}}); got != nil {
+		t.Errorf("meanConfidence without scores = %v, want nil", *got)
+	}
+
+	got := meanConfidence([]Segment{{Confidence: score(0.4)}, {Text: "unscored"}, {Confidence: score(0.8)}})
+	if got == nil || math.Abs(*got-0.6) > 1e-9 {
+		t.Errorf("meanConfidence = %v, want 0.6", got)
+	}
+}
+
+func TestComputeStatistics(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "B", Start: 12, End: 15, Text: "and so on"},
+		{Speaker: "A", Start: 0, End: 4, Text: "hello there"},
+		{Speaker: "B", Start: 3, End: 6, Text: "hi"},
+		{Speaker: "A", Start: 6, End: 9, Text: "how are you"},
+	}
+
+	got := computeStatistics(segments, 2)
+	want := Statistics{
+		SegmentCount:      4,
+		SpeakerCount:      2,
+		TotalDuration:     15,
+		WordCount:         9,
+		WordsPerMinute:    9 / (13.0 / 60),
+		SilenceDuration:   3,
+		OverlapCount:      1,
+		TurnCount:         4,
+		InterruptionCount: 1,
+	}
+
+	if math.Abs(got.WordsPerMinute-want.WordsPerMinute) > 1e-9 {
+		t.Errorf("WordsPerMinute = %v, want %v", got.WordsPerMinute, want.WordsPerMinute)
+	}
+	got.WordsPerMinute = want.WordsPerMinute
+	if got != want {
+		t.Errorf("computeStatistics = %+v, want %+v", got, want)
+	}
+
+	if empty := computeStatistics(nil, 2); empty != (Statistics{}) {
+		t.Errorf("computeStatistics(nil) = %+v, want zero value", empty)
+	}
+}
+
+func TestUniqueSpeakers(t *testing.T) {
+	segments := []Segment{{Speaker: "Charlie"}, {Speaker: "Alice"}, {Speaker: "Charlie"}, {Speaker: "Bob"}}
+	want := []string{"Alice", "Bob", "Charlie"}
+	if got := uniqueSpeakers(segments); !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueSpeakers = %v, want %v", got, want)
+	}
+}
+
+func TestBuildTextExport(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 2, Text: "first"},
+		{Speaker: "A", Start: 2.5, End: 4, Text: "second"},
+		{Speaker: "B", Start: 3725, End: 3730, Text: "late"},
+	}
+
+	tests := []struct {
+		name     string
+		segments []Segment
+		want     string
+	}{
+		{
+			"raw",
+			segments,
+			"[0:00 - 0:02] A: first\n\n[0:02 - 0:04] A: second\n\n[1:02:05 - 1:02:10] B: late\n\n",
+		},
+		{
+			"consolidated",
+			blocksAsSegments(consolidateByThreshold(segments, 1, 0, 0)),
+			"[0:00 - 0:04] A: first second\n\n[1:02:05 - 1:02:10] B: late\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildTextExport(tt.segments); got != tt.want {
+				t.Errorf("buildTextExport = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewSegmentsTextExport(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 2, Text: "first"},
+		{Speaker: "A", Start: 2.5, End: 4, Text: "second"},
+		{Speaker: "B", Start: 5, End: 6, Text: "reply"},
+	}
+	blocks := consolidateByThreshold(segments, 1, 0, 0)
+
+	tests := []struct {
+		name         string
+		blocks       []ConsolidatedSegment
+		consolidated bool
+		want         string
+		wantFellBack bool
+	}{
+		{"raw", blocks, false, "[0:00 - 0:02] A: first\n\n[0:02 - 0:04] A: second\n\n[0:05 - 0:06] B: reply\n\n", false},
+		{"consolidated", blocks, true, "[0:00 - 0:04] A: first second\n\n[0:05 - 0:06] B: reply\n\n", false},
+		{"consolidated without blocks", nil, true, "[0:00 - 0:02] A: first\n\n[0:02 - 0:04] A: second\n\n[0:05 - 0:06] B: reply\n\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, fellBack := viewSegments(segments, tt.blocks, tt.consolidated)
+			if fellBack != tt.wantFellBack {
+				t.Errorf("fellBack = %v, want %v", fellBack, tt.wantFellBack)
+			}
+			if got := buildTextExport(view); got != tt.want {
+				t.Errorf("text export = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentsInRange(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 10, Text: "before"},
+		{Speaker: "B", Start: 8, End: 12, Text: "straddles start"},
+		{Speaker: "A", Start: 15, End: 20, Text: "inside"},
+		{Speaker: "B", Start: 28, End: 35, Text: "straddles end"},
+		{Speaker: "A", Start: 30, End: 40, Text: "after"},
+	}
+
+	tests := []struct {
+		name       string
+		start, end float64
+		want       []string
+	}{
+		{"overlapping window", 10, 30, []string{"straddles start", "inside", "straddles end"}},
+		{"touching edges excluded", 20, 28, nil},
+		{"whole transcript", 0, 40, []string{"before", "straddles start", "inside", "straddles end", "after"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, segment := range segmentsInRange(segments, tt.start, tt.end) {
+				got = append(got, segment.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("segmentsInRange(%v, %v) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSegmentsByQuery(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "Alice", Text: "Budget review"},
+		{Speaker: "Bob", Text: "I agree with Alice"},
+		{Speaker: "Bob", Text: "Next item"},
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 3},
+		{"   ", 3},
+		{"budget", 1},
+		{"ALICE", 2},
+		{"bob next", 1},
+		{"missing", 0},
+	}
+
+	for _, tt := range tests {
+		if got := filterSegmentsByQuery(segments, tt.query); len(got) != tt.want {
+			t.Errorf("filterSegmentsByQuery(%q) returned %d segments, want %d", tt.query, len(got), tt.want)
+		}
+	}
+}
+
+func TestSpeakerFileNames(t *testing.T) {
+	tests := []struct {
+		speakers []string
+		want     []string
+	}{
+		{[]string{"SPEAKER_00", "SPEAKER_01"}, []string{"SPEAKER_00", "SPEAKER_01"}},
+		{[]string{"Ann Lee", "Ann/Lee", "Ann?Lee"}, []string{"Ann_Lee", "Ann_Lee_2", "Ann_Lee_3"}},
+		{[]string{"", "..", "???"}, []string{"speaker", "speaker_2", "speaker_3"}},
+		{[]string{"bob", "Bob"}, []string{"bob", "Bob_2"}},
+		{[]string{"a b", "a_b_2", "a/b"}, []string{"a_b", "a_b_2", "a_b_3"}},
+	}
+
+	for _, tt := range tests {
+		if got := speakerFileNames(tt.speakers); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("speakerFileNames(%q) = %q, want %q", tt.speakers, got, tt.want)
+		}
+	}
+}
+
+func TestPruneSpeakerColors(t *testing.T) {
+	colors := map[string]string{"A": "#111111", "B": "#222222", "C": "#333333"}
+	segments := []Segment{{Speaker: "A"}, {Speaker: "C"}, {Speaker: "A"}}
+
+	pruneSpeakerColors(colors, segments)
+	if want := map[string]string{"A": "#111111", "C": "#333333"}; !reflect.DeepEqual(colors, want) {
+		t.Errorf("pruneSpeakerColors = %v, want %v", colors, want)
+	}
+}
+
+func TestShortcutBinding(t *testing.T) {
+	tests := []struct {
+		key       string
+		wantKeys  []string
+		wantLabel string
+		wantOK    bool
+	}{
+		{"k", []string{"k", "K"}, "K", true},
+		{"K", []string{"k", "K"}, "K", true},
+		{"]", []string{"]"}, "]", true},
+		{"é", []string{"é", "É"}, "É", true},
+		{"", nil, "", false},
+		{" ", nil, "", false},
+		{"ab", nil, "", false},
+		{"Enter", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		keys, label, ok := shortcutBinding(tt.key)
+		if !reflect.DeepEqual(keys, tt.wantKeys) || label != tt.wantLabel || ok != tt.wantOK {
+			t.Errorf("shortcutBinding(%q) = %q, %q, %v, want %q, %q, %v", tt.key, keys, label, ok, tt.wantKeys, tt.wantLabel, tt.wantOK)
+		}
+	}
+}
+
+func TestFileStem(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"meeting.mp3", "meeting"},
+		{"Meeting.JSON", "meeting"},
+		{"team.sync.2024.wav", "team.sync.2024"},
+		{"noextension", "noextension"},
+		{".hidden", ".hidden"},
+	}
+
+	for _, tt := range tests {
+		if got := fileStem(tt.name); got != tt.want {
+			t.Errorf("fileStem(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestActiveWordIndex(t *testing.T) {
+	words := []Word{
+		{Word: "hello", Start: 1, End: 1.4},
+		{Word: "there", Start: 1.5, End: 2},
+		{Word: "friend", Start: 2, End: 2.6},
+	}
+
+	tests := []struct {
+		t    float64
+		want int
+	}{
+		{0.5, -1},
+		{1, 0},
+		{1.2, 0},
+		{1.45, -1},
+		{1.5, 1},
+		{2, 2},
+		{2.6, 2},
+		{3, -1},
+	}
+
+	for _, tt := range tests {
+		if got := activeWordIndex(words, tt.t); got != tt.want {
+			t.Errorf("activeWordIndex(%v) = %d, want %d", tt.t, got, tt.want)
+		}
+	}
+
+	if got := activeWordIndex(nil, 1); got != -1 {
+		t.Errorf("activeWordIndex with no words = %d, want -1", got)
+	}
+}
+
+func TestSegmentIndexAt(t *testing.T) {
+	segments := []Segment{
+		{Start: 0, End: 2},
+		{Start: 2, End: 5},
+		{Start: 7, End: 9},
+	}
+
+	tests := []struct {
+		t    float64
+		want int
+	}{
+		{-1, -1},
+		{0, 0},
+		{1.9, 0},
+		{2, 1},
+		{6, 1},
+		{7, 2},
+		{20, 2},
+	}
+
+	for _, tt := range tests {
+		if got := segmentIndexAt(segments, tt.t); got != tt.want {
+			t.Errorf("segmentIndexAt(%v) = %d, want %d", tt.t, got, tt.want)
+		}
+	}
+
+	if got := segmentIndexAt(nil, 1); got != -1 {
+		t.Errorf("segmentIndexAt with no segments = %d, want -1", got)
+	}
+}
+
+func TestReorderSegmentsAfterTimingEdit(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 2},
+		{Speaker: "B", Start: 2, End: 5},
+		{Speaker: "C", Start: 7, End: 9},
+	}
+	selected := map[int]bool{0: true, 2: true}
+
+	segments[0].Start, segments[0].End = 10, 12
+	selected = reorderSegments(segments, selected)
+
+	var speakers []string
+	for _, segment := range segments {
+		speakers = append(speakers, segment.Speaker)
+	}
+	if want := []string{"B", "C", "A"}; !reflect.DeepEqual(speakers, want) {
+		t.Fatalf("order after edit = %v, want %v", speakers, want)
+	}
+	if want := map[int]bool{1: true, 2: true}; !reflect.DeepEqual(selected, want) {
+		t.Errorf("selection after edit = %v, want %v", selected, want)
+	}
+
+	if i := segmentIndexAt(segments, 11); i != 2 || segments[i].Speaker != "A" {
+		t.Errorf("segmentIndexAt(11) = %d, want the edited segment", i)
+	}
+	if i := segmentIndexAt(segments, 3); i != 0 || segments[i].Speaker != "B" {
+		t.Errorf("segmentIndexAt(3) = %d, want segment B", i)
+	}
+}
+
+func TestConsolidatedBlocksKeepSourceSegments(t *testing.T) {
+	segments := []Segment{
+		{Speaker: "A", Start: 0, End: 2, Text: "we should start"},
+		{Speaker: "A", Start: 2.5, End: 3, Text: "now"},
+		{Speaker: "B", Start: 3, End: 5, Text: "agreed, go ahead"},
+		{Speaker: "B", Start: 9, End: 10, Text: "ok"},
+		{Speaker: "A", Start: 10, End: 14, Text: "first item is the budget"},
+	}
+
+	for _, threshold := range []float64{0, 1, 5, math.Inf(1)} {
+		total := 0
+		for _, block := range consolidateByThreshold(segments, threshold, 0, 0) {
+			words := 0
+			texts := make([]string, len(block.Segments))
+			for i, source := range block.Segments {
+				words += len(strings.Fields(source.Text))
+				texts[i] = source.Text
+			}
+
+			if block.WordCount != words {
+				t.Errorf("threshold %v: block at %v has WordCount %d, sources have %d words", threshold, block.Start, block.WordCount, words)
+			}
+			if joined := strings.Join(texts, " "); block.Text != joined {
+				t.Errorf("threshold %v: block text %q does not match sources %q", threshold, block.Text, joined)
+			}
+			total += len(block.Segments)
+		}
+
+		if total != len(segments) {
+			t.Errorf("threshold %v: blocks hold %d source segments, want %d", threshold, total, len(segments))
+		}
+	}
+}
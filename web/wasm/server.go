@@ -1,27 +1,96 @@
+//go:build !js
+
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 )
 
-func main() {
-	port := "8080"
-	if len(os.Args) > 1 {
-		port = os.Args[1]
+var audioExtensions = map[string]bool{
+	".mp3": true,
+	".wav": true,
+	".m4a": true,
+	".ogg": true,
+}
+
+func serveAudio(w http.ResponseWriter, r *http.Request, root http.FileSystem) bool {
+	f, err := root.Open(path.Clean("/" + r.URL.Path))
+	if err != nil {
+		return false
 	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+	return true
+}
+
+var hashedAssetPattern = regexp.MustCompile(`\.[0-9a-fA-F]{8,}\.[a-z0-9]+$`)
+
+// cacheControlFor marks only content-hashed assets as immutable. main.wasm and
+// wasm_exec.js keep their names across builds, so caching them for a year
+// would serve a stale build; they are revalidated with no-cache instead.
+func cacheControlFor(name string) string {
+	switch ext := strings.ToLower(filepath.Ext(name)); {
+	case hashedAssetPattern.MatchString(name):
+		return "public, max-age=31536000, immutable"
+	case ext == ".html" || ext == ".wasm" || ext == ".js":
+		return "no-cache"
+	default:
+		return ""
+	}
+}
+
+func exists(root http.FileSystem, name string) bool {
+	f, err := root.Open(name)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
 
-	// Get current directory
-	dir, err := os.Getwd()
+func main() {
+	cwd, err := os.Getwd()
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	dirFlag := flag.String("dir", cwd, "directory to serve")
+	port := flag.String("port", "8080", "port to listen on")
+	flag.Parse()
+
+	dir, err := filepath.Abs(*dirFlag)
+	if err != nil {
+		log.Fatalf("❌ Invalid directory %q: %v", *dirFlag, err)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Cannot serve %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+	if !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "❌ Cannot serve %s: not a directory\n", dir)
+		os.Exit(1)
+	}
+
 	// Create file server
-	fs := http.FileServer(http.Dir(dir))
+	root := http.Dir(dir)
+	fs := http.FileServer(root)
 
 	// Add WASM MIME type support
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -29,18 +98,34 @@ func main() {
 		if filepath.Ext(r.URL.Path) == ".wasm" {
 			w.Header().Set("Content-Type", "application/wasm")
 		}
-		
+
 		// Add CORS headers for development
 		w.Header().Set("Cross-Origin-Embedder-Policy", "require-corp")
 		w.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
-		
+
+		// Fall back to index.html for the root and for unknown extensionless paths
+		if requestPath := path.Clean("/" + r.URL.Path); requestPath == "/" || (path.Ext(requestPath) == "" && !exists(root, requestPath)) {
+			w.Header().Set("Cache-Control", cacheControlFor("index.html"))
+			http.ServeFile(w, r, filepath.Join(dir, "index.html"))
+			return
+		}
+
+		if cacheControl := cacheControlFor(r.URL.Path); cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+
+		// Serve audio through ServeContent so Range requests get 206 responses
+		if audioExtensions[strings.ToLower(filepath.Ext(r.URL.Path))] && serveAudio(w, r, root) {
+			return
+		}
+
 		fs.ServeHTTP(w, r)
 	})
 
-	fmt.Printf("🚀 AudioPipe WASM Server starting on http://localhost:%s\n", port)
+	fmt.Printf("🚀 AudioPipe WASM Server starting on http://localhost:%s\n", *port)
 	fmt.Printf("📁 Serving files from: %s\n", dir)
-	fmt.Printf("🌐 Open http://localhost:%s in your browser\n", port)
+	fmt.Printf("🌐 Open http://localhost:%s in your browser\n", *port)
 	fmt.Printf("⏹️  Press Ctrl+C to stop\n\n")
 
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	log.Fatal(http.ListenAndServe(":"+*port, nil))
 }
@@ -0,0 +1,29 @@
+//go:build !js
+
+package main
+
+import "testing"
+
+func TestCacheControlFor(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"index.html", "no-cache"},
+		{"/main.wasm", "no-cache"},
+		{"/wasm_exec.js", "no-cache"},
+		{"/sw.js", "no-cache"},
+		{"/app.3f9a1c2e.js", "public, max-age=31536000, immutable"},
+		{"/main.0123456789abcdef.wasm", "public, max-age=31536000, immutable"},
+		{"/styles.deadbeef.css", "public, max-age=31536000, immutable"},
+		{"/app.v2.js", "no-cache"},
+		{"/terminal-styles.css", ""},
+		{"/audio/clip.mp3", ""},
+	}
+
+	for _, tt := range tests {
+		if got := cacheControlFor(tt.name); got != tt.want {
+			t.Errorf("cacheControlFor(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}